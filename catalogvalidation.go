@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type DiagnosticSeverity string
+
+const (
+	DiagnosticSeverityError   DiagnosticSeverity = "error"
+	DiagnosticSeverityWarning DiagnosticSeverity = "warning"
+)
+
+// CatalogDiagnostic is a single problem found in a module's catalog entry. Field is a
+// JSON path into the module, e.g. "dependencies[0].id".
+type CatalogDiagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Code     string             `json:"code"`
+	Field    string             `json:"field"`
+	Message  string             `json:"message"`
+}
+
+type ModuleDiagnostics struct {
+	ModuleID    string              `json:"moduleId"`
+	Diagnostics []CatalogDiagnostic `json:"diagnostics"`
+}
+
+type CatalogValidation struct {
+	Valid    bool                `json:"valid"`
+	Errors   int                 `json:"errors"`
+	Warnings int                 `json:"warnings"`
+	Modules  []ModuleDiagnostics `json:"modules"`
+}
+
+// ValidateCatalog checks the module catalog for broken references and malformed entries
+func (s *ModuleService) ValidateCatalog() CatalogValidation {
+	return validateCatalog(s.modules)
+}
+
+func validateCatalog(modules []Module) CatalogValidation {
+	byModule := make(map[string][]CatalogDiagnostic)
+	report := func(moduleID string, severity DiagnosticSeverity, code string, field string, format string, args ...any) {
+		byModule[moduleID] = append(byModule[moduleID], CatalogDiagnostic{
+			Severity: severity,
+			Code:     code,
+			Field:    field,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	catalog := make(map[string]Module, len(modules))
+	for _, module := range modules {
+		if _, ok := catalog[module.ID]; ok {
+			report(module.ID, DiagnosticSeverityError, "duplicate-module-id", "id", "module ID %q is used by more than one module", module.ID)
+			continue
+		}
+		catalog[module.ID] = module
+	}
+
+	// Components are deployed side by side, so their IDs should be unique across the catalog
+	componentOwners := make(map[string]string)
+
+	for _, module := range modules {
+		if strings.TrimSpace(module.ID) == "" {
+			report(module.ID, DiagnosticSeverityError, "missing-id", "id", "module %q has no ID", module.Name)
+		}
+		if strings.TrimSpace(module.Name) == "" {
+			report(module.ID, DiagnosticSeverityError, "missing-name", "name", "module has no name")
+		}
+		if strings.TrimSpace(module.Version) == "" {
+			report(module.ID, DiagnosticSeverityWarning, "missing-version", "version", "module has no version")
+		}
+
+		for i, dep := range module.Dependencies {
+			field := fmt.Sprintf("dependencies[%d]", i)
+			target, ok := catalog[dep.ID]
+			switch {
+			case dep.ID == module.ID:
+				report(module.ID, DiagnosticSeverityError, "self-dependency", field+".id", "module depends on itself")
+			case !ok:
+				report(module.ID, DiagnosticSeverityError, "unknown-dependency", field+".id", "dependency %q does not refer to a module in the catalog", dep.ID)
+			default:
+				if dep.Name != "" && dep.Name != target.Name {
+					report(module.ID, DiagnosticSeverityWarning, "dependency-name-mismatch", field+".name", "dependency %q is named %q but the module is called %q", dep.ID, dep.Name, target.Name)
+				}
+				if dep.Version != "" && target.Version != "" && dep.Version != target.Version {
+					report(module.ID, DiagnosticSeverityWarning, "dependency-version-mismatch", field+".version", "dependency %q requires version %s but the catalog has %s", dep.ID, dep.Version, target.Version)
+				}
+			}
+		}
+
+		seen := make(map[string]bool)
+		for i, component := range module.Components {
+			field := fmt.Sprintf("components[%d]", i)
+			switch {
+			case strings.TrimSpace(component.ID) == "":
+				report(module.ID, DiagnosticSeverityError, "missing-component-id", field+".id", "component %q has no ID", component.Name)
+			case seen[component.ID]:
+				report(module.ID, DiagnosticSeverityError, "duplicate-component-id", field+".id", "component ID %q is used more than once in this module", component.ID)
+			case componentOwners[component.ID] != "" && componentOwners[component.ID] != module.ID:
+				report(module.ID, DiagnosticSeverityWarning, "shared-component-id", field+".id", "component ID %q is also used by module %q", component.ID, componentOwners[component.ID])
+			}
+			seen[component.ID] = true
+			if componentOwners[component.ID] == "" {
+				componentOwners[component.ID] = module.ID
+			}
+
+			switch component.Type {
+			case ComponentTypeBackend, ComponentTypeFrontend, ComponentTypeApiGateway, ComponentTypeSetup:
+			default:
+				report(module.ID, DiagnosticSeverityError, "invalid-component-type", field+".type", "component %q has unknown type %q", component.ID, component.Type)
+			}
+		}
+
+		if err := checkConfigSchema(module); err != nil {
+			report(module.ID, DiagnosticSeverityError, "invalid-config-schema", "configSchema", "%s", err.Error())
+		}
+		if module.Chart != nil {
+			if err := validateChart(*module.Chart); err != nil {
+				report(module.ID, DiagnosticSeverityError, "invalid-chart", "chart", "%s", err.Error())
+			}
+		}
+
+		attrs := module.Attributes
+		if attrs.GithubRepo != "" {
+			if repo, err := parseRepoURL(attrs.GithubRepo); err != nil {
+				report(module.ID, DiagnosticSeverityError, "invalid-url", "attributes.githubRepo", "%s", err.Error())
+			} else if !repo.IsGitHub() {
+				report(module.ID, DiagnosticSeverityWarning, "not-github", "attributes.githubRepo", "%s is not hosted on GitHub, its README can't be shown", attrs.GithubRepo)
+			}
+		}
+		checkURL := func(field string, raw string) {
+			if raw == "" {
+				return
+			}
+			if err := validateWebURL(raw); err != nil {
+				report(module.ID, DiagnosticSeverityError, "invalid-url", field, "%s", err.Error())
+			}
+		}
+		checkURL("attributes.documentation", attrs.Documentation)
+		checkURL("attributes.website", attrs.Website)
+
+		registries := make([]string, 0, len(attrs.Packages))
+		for registry := range attrs.Packages {
+			registries = append(registries, registry)
+		}
+		sort.Strings(registries)
+		for _, registry := range registries {
+			checkURL("attributes.packages."+registry, attrs.Packages[registry])
+		}
+	}
+
+	// A module that depends on itself was reported above. In larger cycles the modules
+	// aren't necessarily in dependency order, so only two of them read as a path.
+	for _, cycle := range findDependencyCycles(modules) {
+		var message string
+		switch len(cycle) {
+		case 1:
+			continue
+		case 2:
+			message = fmt.Sprintf("module is part of a dependency cycle: %s -> %s -> %s", cycle[0], cycle[1], cycle[0])
+		default:
+			message = fmt.Sprintf("module is part of a dependency cycle: modules %s and %s depend on each other", strings.Join(cycle[:len(cycle)-1], ", "), cycle[len(cycle)-1])
+		}
+		for _, id := range cycle {
+			report(id, DiagnosticSeverityError, "dependency-cycle", "dependencies", "%s", message)
+		}
+	}
+
+	validation := CatalogValidation{Modules: []ModuleDiagnostics{}}
+	reported := make(map[string]bool)
+	collect := func(id string) {
+		if reported[id] || len(byModule[id]) == 0 {
+			return
+		}
+		reported[id] = true
+		for _, diagnostic := range byModule[id] {
+			if diagnostic.Severity == DiagnosticSeverityError {
+				validation.Errors++
+			} else {
+				validation.Warnings++
+			}
+		}
+		validation.Modules = append(validation.Modules, ModuleDiagnostics{ModuleID: id, Diagnostics: byModule[id]})
+	}
+	for _, module := range modules {
+		collect(module.ID)
+	}
+	validation.Valid = validation.Errors == 0
+	return validation
+}
+
+// validateWebURL checks that raw is an absolute http or https URL
+func validateWebURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("URL %q must use http or https", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("URL %q has no host", raw)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateCatalogDependencyCycles(t *testing.T) {
+	module := func(id string, dependencies ...string) Module {
+		m := Module{ID: id, Name: id, Version: "1.0.0"}
+		for _, dep := range dependencies {
+			m.Dependencies = append(m.Dependencies, ModuleDependency{ID: dep})
+		}
+		return m
+	}
+	validation := validateCatalog([]Module{
+		module("self", "self"),
+		module("a", "b"),
+		module("b", "a"),
+		module("x", "y"),
+		module("y", "z"),
+		module("z", "x"),
+		module("leaf"),
+	})
+
+	type diagnostic struct{ code, message string }
+	got := make(map[string][]diagnostic)
+	for _, m := range validation.Modules {
+		for _, d := range m.Diagnostics {
+			got[m.ModuleID] = append(got[m.ModuleID], diagnostic{d.Code, d.Message})
+		}
+	}
+	triangle := diagnostic{"dependency-cycle", "module is part of a dependency cycle: modules x, y and z depend on each other"}
+	want := map[string][]diagnostic{
+		"self": {{"self-dependency", "module depends on itself"}},
+		"a":    {{"dependency-cycle", "module is part of a dependency cycle: a -> b -> a"}},
+		"b":    {{"dependency-cycle", "module is part of a dependency cycle: a -> b -> a"}},
+		"x":    {triangle},
+		"y":    {triangle},
+		"z":    {triangle},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+	if validation.Valid || validation.Errors != 6 {
+		t.Errorf("valid = %v with %d errors, want 6 errors", validation.Valid, validation.Errors)
+	}
+}
@@ -0,0 +1,11 @@
+{{- define "blocc-module.labels" -}}
+app.kubernetes.io/managed-by: blocc-ui
+app.kubernetes.io/part-of: {{ .Values.blocc.solution }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+blocc.dev/environment: {{ .Values.blocc.environment }}
+blocc.dev/module: {{ .Values.blocc.module }}
+{{- end }}
+
+{{- define "blocc-module.config" -}}
+{{- omit .Values "blocc" "global" "components" "image" | toYaml }}
+{{- end }}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// selectComponents picks the components to sync from the plan of an environment. IDs are
+// component IDs, or moduleId/componentId where a component ID is used by several modules.
+func selectComponents(plan []ComponentResourcePlan, componentIds []string) ([]ComponentResourcePlan, error) {
+	if len(componentIds) == 0 {
+		return nil, fmt.Errorf("no components selected")
+	}
+	var selected []ComponentResourcePlan
+	seen := make(map[string]bool)
+	for _, id := range componentIds {
+		var matches []ComponentResourcePlan
+		for _, component := range plan {
+			if component.ComponentID == id || component.ModuleID+"/"+component.ComponentID == id {
+				matches = append(matches, component)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("component %q is not deployed in this environment", id)
+		case 1:
+		default:
+			var ids []string
+			for _, match := range matches {
+				ids = append(ids, match.ModuleID+"/"+match.ComponentID)
+			}
+			return nil, fmt.Errorf("component %q is ambiguous, use one of %s", id, strings.Join(ids, ", "))
+		}
+		key := matches[0].ModuleID + "/" + matches[0].ComponentID
+		if !seen[key] {
+			seen[key] = true
+			selected = append(selected, matches[0])
+		}
+	}
+	return selected, nil
+}
+
+// SyncComponents re-applies the desired state of the selected components of an environment
+// and restarts them, leaving the rest of the environment alone. Every component is a step
+// of the returned operation, so a component that fails to sync doesn't stop the others
+// and the operation's steps show the result per component. Afterwards the status of the
+// affected modules and the environment reflects the outcome, see reconcileStatus.
+func (s *SolutionService) SyncComponents(solutionId string, environmentId string, componentIds []string) (string, error) {
+	env, components, err := s.beginPlannedTransition(solutionId, environmentId, EnvironmentStatusSyncing, func(plan []ComponentResourcePlan) ([]ComponentResourcePlan, error) {
+		return selectComponents(plan, componentIds)
+	}, EnvironmentStatusRunning, EnvironmentStatusStopped, EnvironmentStatusError)
+	if err != nil {
+		return "", err
+	}
+	// A stopped environment is synced with its components stopped
+	if env.Status == EnvironmentStatusStopped {
+		for i := range components {
+			components[i].Replicas = 0
+		}
+	}
+
+	steps := make([]string, len(components))
+	for i, component := range components {
+		steps[i] = fmt.Sprintf("Sync %s/%s", component.ModuleID, component.ComponentID)
+	}
+	op := s.operations.start(Operation{
+		Kind:          "sync",
+		Title:         fmt.Sprintf("Sync %d components in %s", len(components), env.Name),
+		SolutionID:    solutionId,
+		EnvironmentID: environmentId,
+	}, steps, func(ctx context.Context, run *operationRun) error {
+		ctx, cancel := context.WithTimeout(ctx, deployTimeout)
+		defer cancel()
+
+		// synced tells for every module with a selected component whether all of them synced
+		synced := make(map[string]bool)
+		var failed []string
+		for i, component := range components {
+			run.step(steps[i])
+			if _, ok := synced[component.ModuleID]; !ok {
+				synced[component.ModuleID] = true
+			}
+			if err := s.deployer.Sync(ctx, solutionId, env, component); err != nil {
+				if ctx.Err() != nil {
+					s.reconcileStatus(solutionId, environmentId, env.Status, nil)
+					return err
+				}
+				run.fail(err)
+				synced[component.ModuleID] = false
+				failed = append(failed, component.ModuleID+"/"+component.ComponentID)
+			}
+		}
+		s.reconcileStatus(solutionId, environmentId, env.Status, synced)
+		if len(failed) > 0 {
+			return fmt.Errorf("%d of %d components failed to sync: %s", len(failed), len(components), strings.Join(failed, ", "))
+		}
+		return nil
+	})
+	return op.ID, nil
+}
+
+// reconcileStatus settles an environment after a sync. Modules with a component that failed
+// to sync are in error, modules that synced are back in the status the environment was
+// in, and modules that weren't synced keep theirs. The environment is in error while any
+// of its modules is. A nil synced means the sync was interrupted, which leaves the
+// environment in error since its state is unknown.
+func (s *SolutionService) reconcileStatus(solutionId string, environmentId string, previous EnvironmentStatus, synced map[string]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	solution, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return
+	}
+	solution.UpdatedAt = time.Now()
+	if synced == nil {
+		env.Status = EnvironmentStatusError
+		return
+	}
+
+	desired := previous
+	if desired == EnvironmentStatusError {
+		desired = EnvironmentStatusRunning
+	}
+	env.Status = desired
+	var names []string
+	for i := range env.Modules {
+		module := &env.Modules[i]
+		if ok, selected := synced[module.ModuleID]; selected {
+			names = append(names, module.ModuleID)
+			if ok {
+				module.Status = desired
+			} else {
+				module.Status = EnvironmentStatusError
+			}
+		}
+		if module.Status == EnvironmentStatusError {
+			env.Status = EnvironmentStatusError
+		}
+	}
+	s.recordDeployment(solutionId, *env, DeploymentEventSync, fmt.Sprintf("Synced components of %s", strings.Join(names, ", ")), nil)
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// imageRegistry is where the images of module components are published, one image per
+// component named <registry>/<module>/<component>:<module version>
+const imageRegistry = "registry.stacc.dev"
+
+// composeContainerPort is the port components serve on inside their container
+const composeContainerPort = "8080"
+
+// composeFile is the part of the Compose file format the compose deployer uses
+type composeFile struct {
+	Name     string                    `yaml:"name"`
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image       string            `yaml:"image"`
+	Restart     string            `yaml:"restart"`
+	Environment map[string]string `yaml:"environment"`
+	Labels      map[string]string `yaml:"labels"`
+	Ports       []string          `yaml:"ports,omitempty"`
+	Deploy      composeDeploy     `yaml:"deploy"`
+}
+
+type composeDeploy struct {
+	Replicas  int              `yaml:"replicas"`
+	Resources composeResources `yaml:"resources,omitempty"`
+}
+
+type composeResources struct {
+	Limits       *composeQuantities `yaml:"limits,omitempty"`
+	Reservations *composeQuantities `yaml:"reservations,omitempty"`
+}
+
+type composeQuantities struct {
+	CPUs   string `yaml:"cpus,omitempty"`
+	Memory string `yaml:"memory,omitempty"`
+}
+
+// composeServiceName is the service a component runs as
+func composeServiceName(moduleID string, componentID string) string {
+	return moduleID + "-" + componentID
+}
+
+// composeProject generates the Compose project an environment runs as on this machine,
+// with a service per planned component. The project is named after the environment's
+// namespace, which is unique across solutions. Frontends and API gateways publish their
+// port on a free port of the host.
+func composeProject(solutionID string, env Environment, components []ComponentResourcePlan) composeFile {
+	versions := make(map[string]string, len(env.Modules))
+	for _, module := range env.Modules {
+		versions[module.ModuleID] = module.Version
+	}
+
+	project := composeFile{Name: env.Namespace, Services: make(map[string]composeService, len(components))}
+	for _, component := range components {
+		version, ok := versions[component.ModuleID]
+		if !ok {
+			continue
+		}
+		service := composeService{
+			Image:   fmt.Sprintf("%s/%s/%s:%s", imageRegistry, component.ModuleID, component.ComponentID, version),
+			Restart: "unless-stopped",
+			Environment: map[string]string{
+				"BLOCC_SOLUTION":    solutionID,
+				"BLOCC_ENVIRONMENT": env.ID,
+				"BLOCC_MODULE":      component.ModuleID,
+				"BLOCC_COMPONENT":   component.ComponentID,
+			},
+			Labels: map[string]string{
+				"blocc.dev/solution":    solutionID,
+				"blocc.dev/environment": env.ID,
+				"blocc.dev/module":      component.ModuleID,
+				"blocc.dev/component":   component.ComponentID,
+			},
+			Deploy: composeDeploy{
+				Replicas: component.Replicas,
+				Resources: composeResources{
+					Limits:       composeQuantitiesOf(component.Limits),
+					Reservations: composeQuantitiesOf(component.Requests),
+				},
+			},
+		}
+		switch component.Type {
+		case ComponentTypeSetup:
+			// Setup runs once, it isn't restarted after it's done
+			service.Restart = "no"
+		case ComponentTypeFrontend, ComponentTypeApiGateway:
+			service.Ports = []string{composeContainerPort}
+		}
+		project.Services[composeServiceName(component.ModuleID, component.ComponentID)] = service
+	}
+	return project
+}
+
+// composeQuantitiesOf converts Kubernetes resource quantities to the units Compose uses,
+// dropping those it can't convert
+func composeQuantitiesOf(q ResourceQuantities) *composeQuantities {
+	quantities := composeQuantities{CPUs: composeCPUs(q.CPU), Memory: composeMemory(q.Memory)}
+	if quantities == (composeQuantities{}) {
+		return nil
+	}
+	return &quantities
+}
+
+// composeCPUs converts a CPU quantity such as 500m or 2 to a number of CPUs
+func composeCPUs(cpu string) string {
+	millis, isMillis := strings.CutSuffix(cpu, "m")
+	value, err := strconv.ParseFloat(millis, 64)
+	if err != nil || value <= 0 {
+		return ""
+	}
+	if isMillis {
+		value /= 1000
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// composeMemory converts a memory quantity such as 128Mi or 1G to Docker's units, which
+// are binary. Decimal quantities are converted to bytes, K isn't a Kubernetes suffix.
+func composeMemory(memory string) string {
+	binary := []struct{ suffix, unit string }{{"Ki", "k"}, {"Mi", "m"}, {"Gi", "g"}}
+	for _, u := range binary {
+		if value, ok := strings.CutSuffix(memory, u.suffix); ok {
+			if _, err := strconv.ParseUint(value, 10, 64); err == nil {
+				return value + u.unit
+			}
+			return ""
+		}
+	}
+
+	multiplier := uint64(1)
+	decimal := []struct {
+		suffix     string
+		multiplier uint64
+	}{{"k", 1e3}, {"M", 1e6}, {"G", 1e9}}
+	for _, u := range decimal {
+		if value, ok := strings.CutSuffix(memory, u.suffix); ok {
+			memory, multiplier = value, u.multiplier
+			break
+		}
+	}
+	value, err := strconv.ParseUint(memory, 10, 64)
+	if err != nil || value > math.MaxUint64/multiplier {
+		return ""
+	}
+	return strconv.FormatUint(value*multiplier, 10) + "b"
+}
+
+// composeDeployer runs environments on this machine with Docker Compose. Every change
+// regenerates the environment's project from its planned components and brings the
+// affected services up, so the project always matches the configuration.
+type composeDeployer struct {
+	store *fileStore
+	// plan returns the planned components of an environment
+	plan func(solutionID string, env Environment) ([]ComponentResourcePlan, error)
+}
+
+func newComposeDeployer(plan func(solutionID string, env Environment) ([]ComponentResourcePlan, error)) *composeDeployer {
+	return &composeDeployer{store: newFileStore(defaultDataDir()), plan: plan}
+}
+
+func composeProjectPath(solutionID string, env Environment) []string {
+	return []string{"compose", solutionID, env.ID, "compose.yaml"}
+}
+
+// compose runs a docker compose command against the project of env
+func (d *composeDeployer) compose(ctx context.Context, solutionID string, env Environment, args ...string) ([]byte, error) {
+	args = append([]string{"compose", "--project-name", env.Namespace, "--file", d.store.path(composeProjectPath(solutionID, env)...)}, args...)
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("docker compose %s: %s", args[5], secretMasker.Mask(message))
+		}
+		return nil, fmt.Errorf("docker compose %s: %w", args[5], err)
+	}
+	return out, nil
+}
+
+// up writes the project of env and brings up the services of components, with the
+// replicas of components rather than the planned ones. Components of modules that are no
+// longer in env have no service, their containers are removed as orphans instead.
+func (d *composeDeployer) up(ctx context.Context, solutionID string, env Environment, components []ComponentResourcePlan, args ...string) error {
+	plan, err := d.plan(solutionID, env)
+	if err != nil {
+		return err
+	}
+	replicas := make(map[string]int, len(components))
+	for _, component := range components {
+		replicas[composeServiceName(component.ModuleID, component.ComponentID)] = component.Replicas
+	}
+	var services []string
+	for i, component := range plan {
+		name := composeServiceName(component.ModuleID, component.ComponentID)
+		if n, ok := replicas[name]; ok {
+			plan[i].Replicas = n
+			services = append(services, name)
+		}
+	}
+
+	data, err := yaml.Marshal(composeProject(solutionID, env, plan))
+	if err != nil {
+		return err
+	}
+	if err := d.store.Write(data, composeProjectPath(solutionID, env)...); err != nil {
+		return fmt.Errorf("failed to write compose project: %w", err)
+	}
+	// Without services up would start all of them, only remove the orphans then
+	if len(services) == 0 {
+		_, err = d.compose(ctx, solutionID, env, "up", "--no-start", "--remove-orphans")
+		return err
+	}
+	args = append(append([]string{"up", "--detach", "--remove-orphans"}, args...), services...)
+	_, err = d.compose(ctx, solutionID, env, args...)
+	return err
+}
+
+func (d *composeDeployer) Scale(ctx context.Context, solutionID string, env Environment, components []ComponentResourcePlan) error {
+	return d.up(ctx, solutionID, env, components)
+}
+
+// Apply brings up the components of the release's module. Compose can't run the rendered
+// manifests, the components run from their images as planned instead.
+func (d *composeDeployer) Apply(ctx context.Context, solutionID string, env Environment, release ModuleManifests) error {
+	plan, err := d.plan(solutionID, env)
+	if err != nil {
+		return err
+	}
+	var components []ComponentResourcePlan
+	for _, component := range plan {
+		if component.ModuleID == release.ModuleID {
+			if env.Status != EnvironmentStatusRunning {
+				component.Replicas = 0
+			}
+			components = append(components, component)
+		}
+	}
+	return d.up(ctx, solutionID, env, components)
+}
+
+func (d *composeDeployer) Sync(ctx context.Context, solutionID string, env Environment, component ComponentResourcePlan) error {
+	return d.up(ctx, solutionID, env, []ComponentResourcePlan{component}, "--force-recreate")
+}
+
+func (d *composeDeployer) Teardown(ctx context.Context, solutionID string, env Environment) error {
+	_, ok, err := d.store.Read(composeProjectPath(solutionID, env)...)
+	if err != nil || !ok {
+		return err
+	}
+	if _, err := d.compose(ctx, solutionID, env, "down", "--volumes", "--remove-orphans"); err != nil {
+		return err
+	}
+	return d.store.Remove(composeProjectPath(solutionID, env)...)
+}
+
+// composeContainer is a container as listed by docker compose ps
+type composeContainer struct {
+	Service  string `json:"Service"`
+	State    string `json:"State"`
+	Health   string `json:"Health"`
+	ExitCode int    `json:"ExitCode"`
+}
+
+// parseComposePs reads the output of docker compose ps --format json, which is an array
+// in older versions of Compose and a line per container in newer ones
+func parseComposePs(out []byte) ([]composeContainer, error) {
+	out = bytes.TrimSpace(out)
+	var containers []composeContainer
+	if bytes.HasPrefix(out, []byte("[")) {
+		err := json.Unmarshal(out, &containers)
+		return containers, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var container composeContainer
+		if err := json.Unmarshal(scanner.Bytes(), &container); err != nil {
+			return nil, err
+		}
+		containers = append(containers, container)
+	}
+	return containers, scanner.Err()
+}
+
+func (d *composeDeployer) Status(ctx context.Context, solutionID string, env Environment) ([]componentState, error) {
+	_, ok, err := d.store.Read(composeProjectPath(solutionID, env)...)
+	if err != nil || !ok {
+		// Nothing was ever deployed, so nothing runs
+		return nil, err
+	}
+	out, err := d.compose(ctx, solutionID, env, "ps", "--all", "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+	containers, err := parseComposePs(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker compose ps: %w", err)
+	}
+	plan, err := d.plan(solutionID, env)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]componentState, 0, len(plan))
+	for _, component := range plan {
+		state := componentState{ModuleID: component.ModuleID, ComponentID: component.ComponentID}
+		for _, container := range containers {
+			if container.Service != composeServiceName(component.ModuleID, component.ComponentID) {
+				continue
+			}
+			switch {
+			case container.State == "running" && container.Health != "unhealthy":
+				state.Running++
+			case container.State == "running", container.State == "restarting", container.State == "dead",
+				container.State == "exited" && container.ExitCode != 0:
+				state.Failed = true
+			}
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func (d *composeDeployer) Logs(ctx context.Context, solutionID string, env Environment, component ComponentResourcePlan, tail int) ([]LogEntry, error) {
+	out, err := d.compose(ctx, solutionID, env, "logs", "--no-color", "--no-log-prefix", "--timestamps",
+		"--tail", strconv.Itoa(tail), composeServiceName(component.ModuleID, component.ComponentID))
+	if err != nil {
+		return nil, err
+	}
+	return parseComposeLogs(out), nil
+}
+
+// parseComposeLogs reads the output of docker compose logs --timestamps, newest first
+func parseComposeLogs(out []byte) []LogEntry {
+	entries := []LogEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entry := LogEntry{Level: "INFO", Message: line}
+		if stamp, message, ok := strings.Cut(line, " "); ok {
+			if timestamp, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				entry.Timestamp, entry.Message = timestamp, message
+			}
+		}
+		upper := strings.ToUpper(entry.Message)
+		for _, level := range []string{"ERROR", "WARN", "DEBUG"} {
+			if strings.Contains(upper, level) {
+				entry.Level = level
+				break
+			}
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+	return entries
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestComposeProject(t *testing.T) {
+	env := Environment{
+		ID:        "dev-1",
+		Namespace: "customer-a-dev-1",
+		Modules: []EnvironmentModule{
+			{ModuleID: "decision", Version: "1.2.0"},
+			{ModuleID: "flow", Version: "15.4.0"},
+		},
+	}
+	components := []ComponentResourcePlan{
+		{ModuleID: "decision", ComponentID: "decision-engine", Type: ComponentTypeBackend, Replicas: 2,
+			Requests: ResourceQuantities{CPU: "250m", Memory: "256Mi"}, Limits: ResourceQuantities{CPU: "1", Memory: "1Gi"}},
+		{ModuleID: "decision", ComponentID: "case-manager", Type: ComponentTypeFrontend, Replicas: 1},
+		{ModuleID: "decision", ComponentID: "decision-api", Type: ComponentTypeApiGateway, Replicas: 1},
+		{ModuleID: "flow", ComponentID: "migrate", Type: ComponentTypeSetup, Replicas: 1},
+		{ModuleID: "flow", ComponentID: "process", Type: ComponentTypeBackend, Replicas: 0},
+		// Modules that aren't in the environment have no services
+		{ModuleID: "control-panel", ComponentID: "control-panel-server", Type: ComponentTypeBackend, Replicas: 1},
+	}
+	project := composeProject("demo-solution", env, components)
+
+	if project.Name != "customer-a-dev-1" {
+		t.Errorf("project is named %q, want the namespace", project.Name)
+	}
+	tests := []struct {
+		service   string
+		image     string
+		restart   string
+		ports     []string
+		replicas  int
+		resources composeResources
+	}{
+		{
+			service:  "decision-decision-engine",
+			image:    "registry.stacc.dev/decision/decision-engine:1.2.0",
+			restart:  "unless-stopped",
+			replicas: 2,
+			resources: composeResources{
+				Limits:       &composeQuantities{CPUs: "1", Memory: "1g"},
+				Reservations: &composeQuantities{CPUs: "0.25", Memory: "256m"},
+			},
+		},
+		{
+			service:  "decision-case-manager",
+			image:    "registry.stacc.dev/decision/case-manager:1.2.0",
+			restart:  "unless-stopped",
+			ports:    []string{"8080"},
+			replicas: 1,
+		},
+		{
+			service:  "decision-decision-api",
+			image:    "registry.stacc.dev/decision/decision-api:1.2.0",
+			restart:  "unless-stopped",
+			ports:    []string{"8080"},
+			replicas: 1,
+		},
+		{
+			service:  "flow-migrate",
+			image:    "registry.stacc.dev/flow/migrate:15.4.0",
+			restart:  "no",
+			replicas: 1,
+		},
+		{
+			service:  "flow-process",
+			image:    "registry.stacc.dev/flow/process:15.4.0",
+			restart:  "unless-stopped",
+			replicas: 0,
+		},
+	}
+	if len(project.Services) != len(tests) {
+		t.Errorf("project has %d services, want %d", len(project.Services), len(tests))
+	}
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			service, ok := project.Services[tt.service]
+			if !ok {
+				t.Fatalf("service %s is missing", tt.service)
+			}
+			if service.Image != tt.image {
+				t.Errorf("image = %q, want %q", service.Image, tt.image)
+			}
+			if service.Restart != tt.restart {
+				t.Errorf("restart = %q, want %q", service.Restart, tt.restart)
+			}
+			if !reflect.DeepEqual(service.Ports, tt.ports) {
+				t.Errorf("ports = %v, want %v", service.Ports, tt.ports)
+			}
+			if service.Deploy.Replicas != tt.replicas {
+				t.Errorf("replicas = %d, want %d", service.Deploy.Replicas, tt.replicas)
+			}
+			if !reflect.DeepEqual(service.Deploy.Resources, tt.resources) {
+				t.Errorf("resources = %+v, want %+v", service.Deploy.Resources, tt.resources)
+			}
+			if service.Labels["blocc.dev/environment"] != "dev-1" || service.Environment["BLOCC_SOLUTION"] != "demo-solution" {
+				t.Errorf("service isn't labelled with its environment: %v %v", service.Labels, service.Environment)
+			}
+		})
+	}
+}
+
+func TestComposeCPUs(t *testing.T) {
+	tests := []struct {
+		cpu  string
+		want string
+	}{
+		{"100m", "0.1"},
+		{"1500m", "1.5"},
+		{"2", "2"},
+		{"0.5", "0.5"},
+		{"", ""},
+		{"0", ""},
+		{"-1", ""},
+		{"two", ""},
+	}
+	for _, tt := range tests {
+		if got := composeCPUs(tt.cpu); got != tt.want {
+			t.Errorf("composeCPUs(%q) = %q, want %q", tt.cpu, got, tt.want)
+		}
+	}
+}
+
+func TestComposeMemory(t *testing.T) {
+	tests := []struct {
+		memory string
+		want   string
+	}{
+		{"512Ki", "512k"},
+		{"128Mi", "128m"},
+		{"2Gi", "2g"},
+		{"64k", "64000b"},
+		{"256M", "256000000b"},
+		{"1G", "1000000000b"},
+		{"1048576", "1048576b"},
+		{"64K", ""},
+		{"99999999999G", ""},
+		{"", ""},
+		{"1.5Gi", ""},
+		{"1Ti", ""},
+		{"lots", ""},
+	}
+	for _, tt := range tests {
+		if got := composeMemory(tt.memory); got != tt.want {
+			t.Errorf("composeMemory(%q) = %q, want %q", tt.memory, got, tt.want)
+		}
+	}
+}
+
+func TestParseComposePs(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want []composeContainer
+	}{
+		{
+			name: "array",
+			out:  `[{"Service":"flow-process","State":"running","Health":"healthy","ExitCode":0},{"Service":"flow-migrate","State":"exited","ExitCode":1}]`,
+			want: []composeContainer{
+				{Service: "flow-process", State: "running", Health: "healthy"},
+				{Service: "flow-migrate", State: "exited", ExitCode: 1},
+			},
+		},
+		{
+			name: "lines",
+			out:  "{\"Service\":\"flow-process\",\"State\":\"running\"}\n\n{\"Service\":\"flow-camunda\",\"State\":\"restarting\"}\n",
+			want: []composeContainer{
+				{Service: "flow-process", State: "running"},
+				{Service: "flow-camunda", State: "restarting"},
+			},
+		},
+		{
+			name: "empty",
+			out:  "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseComposePs([]byte(tt.out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseComposePs([]byte("not json\n")); err == nil {
+		t.Error("invalid output was parsed")
+	}
+}
+
+func TestParseComposeLogs(t *testing.T) {
+	out := "2024-05-01T10:00:00.000000001Z Started in 2.1s\n" +
+		"2024-05-01T10:00:02Z WARN slow response from camunda\n" +
+		"\n" +
+		"2024-05-01T10:00:01.5Z error: connection refused\n" +
+		"no timestamp, debug output\n"
+	at := func(value string) time.Time {
+		timestamp, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return timestamp
+	}
+	want := []LogEntry{
+		{Timestamp: at("2024-05-01T10:00:02Z"), Level: "WARN", Message: "WARN slow response from camunda"},
+		{Timestamp: at("2024-05-01T10:00:01.5Z"), Level: "ERROR", Message: "error: connection refused"},
+		{Timestamp: at("2024-05-01T10:00:00.000000001Z"), Level: "INFO", Message: "Started in 2.1s"},
+		{Level: "DEBUG", Message: "no timestamp, debug output"},
+	}
+
+	got := parseComposeLogs([]byte(out))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+	if got := parseComposeLogs(nil); got == nil || len(got) != 0 {
+		t.Errorf("no output parsed to %#v, want no entries", got)
+	}
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigRevision is a saved version of an environment's configuration. YAML is only
+// filled in when a single revision is requested.
+type ConfigRevision struct {
+	Revision     int       `json:"revision"`
+	Author       string    `json:"author"`
+	Message      string    `json:"message"`
+	CreatedAt    time.Time `json:"createdAt" ts_type:"string"`
+	RollbackFrom int       `json:"rollbackFrom,omitempty"`
+	YAML         string    `json:"yaml,omitempty"`
+}
+
+type ConfigChangeKind string
+
+const (
+	ConfigChangeAdded   ConfigChangeKind = "added"
+	ConfigChangeRemoved ConfigChangeKind = "removed"
+	ConfigChangeChanged ConfigChangeKind = "changed"
+)
+
+// ConfigChange is a single value that differs between two configurations. Path is a
+// dotted path into the document, e.g. "spec.modules.flow.values.replicas". Secret is set
+// when either side is a secret reference, Before and After then hold the references.
+type ConfigChange struct {
+	Path   string           `json:"path"`
+	Kind   ConfigChangeKind `json:"kind"`
+	Before any              `json:"before"`
+	After  any              `json:"after"`
+	Secret bool             `json:"secret"`
+}
+
+type ConfigDiff struct {
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	Changes []ConfigChange `json:"changes"`
+}
+
+// configHistoryPath is where the revisions of an environment's configuration are stored
+func configHistoryPath(solutionID string, environmentID string, elem ...string) []string {
+	return append([]string{"solutions", solutionID, "environments", environmentID, "history"}, elem...)
+}
+
+func configRevisionPath(solutionID string, environmentID string, revision int) []string {
+	return configHistoryPath(solutionID, environmentID, fmt.Sprintf("%06d.yaml", revision))
+}
+
+// currentAuthor returns the name of the user running the app, used as the author of revisions
+func currentAuthor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	for _, key := range []string{"USER", "USERNAME"} {
+		if name := os.Getenv(key); name != "" {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// loadConfigHistory returns the revisions of an environment's configuration, oldest first
+func (s *SolutionService) loadConfigHistory(solutionId string, environmentId string) ([]ConfigRevision, error) {
+	data, ok, err := s.store.Read(configHistoryPath(solutionId, environmentId, "index.json")...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration history: %w", err)
+	}
+	revisions := []ConfigRevision{}
+	if !ok {
+		return revisions, nil
+	}
+	if err := json.Unmarshal(data, &revisions); err != nil {
+		return nil, fmt.Errorf("failed to read configuration history: %w", err)
+	}
+	return revisions, nil
+}
+
+// recordConfigRevision adds content as the newest revision of an environment's configuration
+func (s *SolutionService) recordConfigRevision(solutionId string, environmentId string, content string, revision ConfigRevision) (ConfigRevision, error) {
+	revisions, err := s.loadConfigHistory(solutionId, environmentId)
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+
+	revision.Revision = 1
+	if len(revisions) > 0 {
+		revision.Revision = revisions[len(revisions)-1].Revision + 1
+	}
+	revision.Author = currentAuthor()
+	revision.CreatedAt = time.Now()
+	revision.YAML = ""
+
+	// The content goes first, an index entry without content would be a broken revision
+	if err := s.store.Write([]byte(content), configRevisionPath(solutionId, environmentId, revision.Revision)...); err != nil {
+		return ConfigRevision{}, fmt.Errorf("failed to save configuration revision: %w", err)
+	}
+	data, err := json.MarshalIndent(append(revisions, revision), "", "  ")
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+	if err := s.store.Write(data, configHistoryPath(solutionId, environmentId, "index.json")...); err != nil {
+		return ConfigRevision{}, fmt.Errorf("failed to save configuration history: %w", err)
+	}
+	return revision, nil
+}
+
+// GetConfigHistory returns the revisions of an environment's configuration, newest first
+func (s *SolutionService) GetConfigHistory(solutionId string, environmentId string) ([]ConfigRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, _, err := s.findEnvironment(solutionId, environmentId); err != nil {
+		return nil, err
+	}
+	revisions, err := s.loadConfigHistory(solutionId, environmentId)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+// GetConfigRevision returns a single revision of an environment's configuration including its content
+func (s *SolutionService) GetConfigRevision(solutionId string, environmentId string, revision int) (ConfigRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.configRevision(solutionId, environmentId, revision)
+}
+
+// configRevision is GetConfigRevision for callers that already hold s.mu
+func (s *SolutionService) configRevision(solutionId string, environmentId string, revision int) (ConfigRevision, error) {
+	if _, _, err := s.findEnvironment(solutionId, environmentId); err != nil {
+		return ConfigRevision{}, err
+	}
+	revisions, err := s.loadConfigHistory(solutionId, environmentId)
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+	for _, r := range revisions {
+		if r.Revision != revision {
+			continue
+		}
+		data, ok, err := s.store.Read(configRevisionPath(solutionId, environmentId, revision)...)
+		if err != nil {
+			return ConfigRevision{}, fmt.Errorf("failed to read configuration revision: %w", err)
+		}
+		if !ok {
+			return ConfigRevision{}, fmt.Errorf("content of revision %d is missing", revision)
+		}
+		r.YAML = string(data)
+		return r, nil
+	}
+	return ConfigRevision{}, fmt.Errorf("revision not found")
+}
+
+// currentConfigContent returns the stored configuration of an environment, or the default one
+func (s *SolutionService) currentConfigContent(solutionId string, env Environment) (string, error) {
+	data, saved, err := s.store.Read(environmentConfigPath(solutionId, env.ID)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to read configuration: %w", err)
+	}
+	if !saved {
+		return defaultEnvironmentConfig(env), nil
+	}
+	return string(data), nil
+}
+
+// configContent returns a revision of an environment's configuration, revision 0 is the current one
+func (s *SolutionService) configContent(solutionId string, env Environment, revision int) (string, error) {
+	if revision == 0 {
+		return s.currentConfigContent(solutionId, env)
+	}
+	r, err := s.configRevision(solutionId, env.ID, revision)
+	if err != nil {
+		return "", err
+	}
+	return r.YAML, nil
+}
+
+// DiffConfigRevisions compares two revisions of an environment's configuration. Revision 0
+// stands for the current configuration.
+func (s *SolutionService) DiffConfigRevisions(solutionId string, environmentId string, fromRevision int, toRevision int) (ConfigDiff, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	from, err := s.configContent(solutionId, *env, fromRevision)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	to, err := s.configContent(solutionId, *env, toRevision)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	label := func(revision int) string {
+		if revision == 0 {
+			return "current"
+		}
+		return fmt.Sprintf("revision %d", revision)
+	}
+	changes, err := diffConfigDocuments(from, to)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	return ConfigDiff{From: label(fromRevision), To: label(toRevision), Changes: changes}, nil
+}
+
+// DiffEnvironmentConfigs compares the current configuration of two environments of a solution
+func (s *SolutionService) DiffEnvironmentConfigs(solutionId string, fromEnvironmentId string, toEnvironmentId string) (ConfigDiff, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, fromEnv, err := s.findEnvironment(solutionId, fromEnvironmentId)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	_, toEnv, err := s.findEnvironment(solutionId, toEnvironmentId)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	from, err := s.currentConfigContent(solutionId, *fromEnv)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	to, err := s.currentConfigContent(solutionId, *toEnv)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	changes, err := diffConfigDocuments(from, to)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	return ConfigDiff{From: fromEnv.Name, To: toEnv.Name, Changes: changes}, nil
+}
+
+// RollbackEnvironmentConfig restores a revision of an environment's configuration and
+// deploys it again. The restored configuration is recorded as a new revision, so the
+// rollback itself can be undone. It's validated like any other save since the modules
+// of the environment may have changed since. The deployment runs in the background, the
+// operation ID in the result tracks it.
+func (s *SolutionService) RollbackEnvironmentConfig(solutionId string, environmentId string, revision int) (ConfigSaveResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return ConfigSaveResult{}, err
+	}
+	if isTransitional(env.Status) {
+		return ConfigSaveResult{}, fmt.Errorf("environment is %s, wait for it to finish", env.Status)
+	}
+	r, err := s.configRevision(solutionId, environmentId, revision)
+	if err != nil {
+		return ConfigSaveResult{}, err
+	}
+
+	result, err := s.saveEnvironmentConfig(solutionId, env, r.YAML, ConfigRevision{
+		Message:      fmt.Sprintf("Roll back to revision %d", revision),
+		RollbackFrom: revision,
+	}, false)
+	if err != nil || !result.Saved {
+		return result, err
+	}
+
+	env.LastDeployed = time.Now()
+	s.recordDeployment(solutionId, *env, DeploymentEventConfig, fmt.Sprintf("Configuration rolled back to revision %d", revision), nil)
+	result.OperationID, err = s.rollOut(solutionId, env, nil, "rollback", fmt.Sprintf("Roll back configuration of %s to revision %d", env.Name, revision))
+	return result, err
+}
+
+// diffConfigDocuments compares the spec of two configuration documents value by value
+func diffConfigDocuments(from string, to string) ([]ConfigChange, error) {
+	var before, after struct {
+		Spec map[string]any `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal([]byte(from), &before); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+	if err := yaml.Unmarshal([]byte(to), &after); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	beforeValues := make(map[string]any)
+	afterValues := make(map[string]any)
+	flattenValues("spec", before.Spec, beforeValues)
+	flattenValues("spec", after.Spec, afterValues)
+
+	changes := []ConfigChange{}
+	for _, path := range sortedKeys(beforeValues) {
+		value, ok := afterValues[path]
+		switch {
+		case !ok:
+			changes = append(changes, ConfigChange{Path: path, Kind: ConfigChangeRemoved, Before: beforeValues[path]})
+		case !reflect.DeepEqual(beforeValues[path], value):
+			changes = append(changes, ConfigChange{Path: path, Kind: ConfigChangeChanged, Before: beforeValues[path], After: value})
+		}
+	}
+	for _, path := range sortedKeys(afterValues) {
+		if _, ok := beforeValues[path]; !ok {
+			changes = append(changes, ConfigChange{Path: path, Kind: ConfigChangeAdded, After: afterValues[path]})
+		}
+	}
+	for i, change := range changes {
+		_, beforeSecret := change.Before.(SecretRef)
+		_, afterSecret := change.After.(SecretRef)
+		changes[i].Secret = beforeSecret || afterSecret
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// flattenValues collects the leaf values of nested mappings by dotted path. Lists and
+// secret references are compared as a whole, and empty mappings have no values so
+// "values: {}" equals no values.
+func flattenValues(prefix string, value any, out map[string]any) {
+	if ref, ok, err := asSecretRef(value); ok && err == nil {
+		out[prefix] = ref
+		return
+	}
+	mapping, ok := value.(map[string]any)
+	if !ok {
+		out[prefix] = value
+		return
+	}
+	for key, child := range mapping {
+		flattenValues(prefix+"."+strings.ReplaceAll(key, ".", "\\."), child, out)
+	}
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigLayer is a level of configuration. Later layers override earlier ones:
+// module defaults, then solution values, then environment overrides.
+type ConfigLayer string
+
+const (
+	ConfigLayerDefault     ConfigLayer = "default"
+	ConfigLayerSolution    ConfigLayer = "solution"
+	ConfigLayerEnvironment ConfigLayer = "environment"
+)
+
+// EffectiveValue is a merged configuration value. Source is the layer that set it and
+// Overrides lists the lower layers that also set it and were overridden.
+type EffectiveValue struct {
+	Value     any           `json:"value"`
+	Source    ConfigLayer   `json:"source"`
+	Overrides []ConfigLayer `json:"overrides"`
+}
+
+// EffectiveConfig is the configuration an environment is deployed with, after merging all layers
+type EffectiveConfig struct {
+	SolutionID      string                               `json:"solutionId"`
+	EnvironmentID   string                               `json:"environmentId"`
+	ResourceProfile EffectiveValue                       `json:"resourceProfile"`
+	Global          map[string]EffectiveValue            `json:"global"`
+	Modules         map[string]map[string]EffectiveValue `json:"modules"`
+	Resources       ResourcePlan                         `json:"resources"`
+}
+
+// SolutionConfig holds the values shared by all environments of a solution
+type SolutionConfig struct {
+	SolutionID      string                    `json:"solutionId"`
+	YAML            string                    `json:"yaml"`
+	ResourceProfile string                    `json:"resourceProfile"`
+	Global          map[string]string         `json:"global"`
+	Modules         map[string]map[string]any `json:"modules"`
+	Saved           bool                      `json:"saved"`
+	UpdatedAt       time.Time                 `json:"updatedAt" ts_type:"string"`
+}
+
+type SolutionConfigSaveResult struct {
+	Saved  bool           `json:"saved"`
+	Config SolutionConfig `json:"config"`
+	Issues []ConfigIssue  `json:"issues"`
+}
+
+// builtinGlobalDefaults are the global values every environment starts from
+var builtinGlobalDefaults = map[string]string{
+	"domainSuffix": defaultDomainSuffix,
+}
+
+// solutionConfigPath is where the configuration shared by a solution's environments is stored
+func solutionConfigPath(solutionID string) []string {
+	return []string{"solutions", solutionID, "config.yaml"}
+}
+
+// defaultSolutionConfig returns the configuration of a solution that was never saved
+func defaultSolutionConfig(solution Solution) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "apiVersion: %s\n", environmentConfigAPIVersion)
+	b.WriteString("kind: Solution\n")
+	b.WriteString("metadata:\n")
+	fmt.Fprintf(&b, "  name: %q\n", solution.Name)
+	b.WriteString("spec:\n")
+	fmt.Fprintf(&b, "  resourceProfile: %s\n", defaultResourceProfile)
+	b.WriteString("  global:\n")
+	b.WriteString("    values:\n")
+	fmt.Fprintf(&b, "      domainSuffix: %s\n", defaultDomainSuffix)
+	b.WriteString("    valuesFrom: {}\n")
+
+	modules := make([]EnvironmentModule, 0, len(solution.Modules))
+	for _, module := range solution.Modules {
+		modules = append(modules, EnvironmentModule{ModuleID: module.ModuleID})
+	}
+	writeModuleValues(&b, modules)
+	return b.String()
+}
+
+// parseSolutionConfig parses the configuration document of a solution. Values may be set
+// for any module the solution declares or that is installed in one of its environments.
+func parseSolutionConfig(content string, solution Solution) (*yaml.Node, configDocument, []ConfigIssue) {
+	configurable := make(map[string]bool)
+	for _, module := range solution.Modules {
+		configurable[module.ModuleID] = true
+	}
+	for _, env := range solution.Environments {
+		for _, module := range env.Modules {
+			configurable[module.ModuleID] = true
+		}
+	}
+	return parseConfigDocument(content, solution.Name, configurable, fmt.Sprintf("solution %q", solution.Name))
+}
+
+func newSolutionConfig(solution Solution, content string, config configDocument) SolutionConfig {
+	envConfig := newEnvironmentConfig(solution.ID, Environment{}, content, config)
+	return SolutionConfig{
+		SolutionID:      solution.ID,
+		YAML:            content,
+		ResourceProfile: envConfig.ResourceProfile,
+		Global:          envConfig.Global,
+		Modules:         envConfig.Modules,
+	}
+}
+
+// loadSolutionDocument returns the stored configuration of a solution, or the default one
+func (s *SolutionService) loadSolutionDocument(solutionId string) (configDocument, error) {
+	solution := s.findSolution(solutionId)
+	if solution == nil {
+		return configDocument{}, fmt.Errorf("solution not found")
+	}
+	data, saved, err := s.store.Read(solutionConfigPath(solutionId)...)
+	if err != nil {
+		return configDocument{}, fmt.Errorf("failed to read solution configuration: %w", err)
+	}
+	content := string(data)
+	if !saved {
+		content = defaultSolutionConfig(*solution)
+	}
+	_, config, _ := parseSolutionConfig(content, *solution)
+	return config, nil
+}
+
+// loadEnvironmentDocument returns the stored configuration of an environment, or the default one
+func (s *SolutionService) loadEnvironmentDocument(solutionId string, env Environment) (configDocument, error) {
+	content, err := s.currentConfigContent(solutionId, env)
+	if err != nil {
+		return configDocument{}, err
+	}
+	_, config, _ := parseEnvironmentConfig(content, env)
+	return config, nil
+}
+
+// effectiveConfig merges module defaults, the solution's values and the environment's
+// overrides for the modules installed in env
+func (s *SolutionService) effectiveConfig(solutionId string, env Environment, solutionDoc configDocument, envDoc configDocument) EffectiveConfig {
+	effective := EffectiveConfig{
+		SolutionID:      solutionId,
+		EnvironmentID:   env.ID,
+		ResourceProfile: EffectiveValue{Value: defaultResourceProfile, Source: ConfigLayerDefault, Overrides: []ConfigLayer{}},
+		Global:          make(map[string]EffectiveValue),
+		Modules:         make(map[string]map[string]EffectiveValue),
+	}
+
+	if profile := solutionDoc.Spec.ResourceProfile; profile != "" {
+		effective.ResourceProfile = effective.ResourceProfile.override(profile, ConfigLayerSolution)
+	}
+	if profile := envDoc.Spec.ResourceProfile; profile != "" {
+		effective.ResourceProfile = effective.ResourceProfile.override(profile, ConfigLayerEnvironment)
+	}
+
+	for key, value := range builtinGlobalDefaults {
+		effective.Global[key] = EffectiveValue{Value: value, Source: ConfigLayerDefault, Overrides: []ConfigLayer{}}
+	}
+	mergeLayer(effective.Global, solutionDoc.Spec.Global.Values, ConfigLayerSolution)
+	mergeLayer(effective.Global, envDoc.Spec.Global.Values, ConfigLayerEnvironment)
+
+	for _, module := range env.Modules {
+		values := make(map[string]EffectiveValue)
+		for key, value := range schemaDefaults(s.moduleConfigSchema(module.ModuleID)) {
+			values[key] = EffectiveValue{Value: value, Source: ConfigLayerDefault, Overrides: []ConfigLayer{}}
+		}
+		mergeLayer(values, solutionDoc.Spec.Modules[module.ModuleID].Values, ConfigLayerSolution)
+		mergeLayer(values, envDoc.Spec.Modules[module.ModuleID].Values, ConfigLayerEnvironment)
+		effective.Modules[module.ModuleID] = values
+	}
+
+	effective.Resources = s.resourcePlan(env, effective.ResourceProfile.Value.(string), solutionDoc, envDoc)
+	return effective
+}
+
+// override returns the value as set by a higher layer, recording the layer it replaces
+func (v EffectiveValue) override(value any, layer ConfigLayer) EffectiveValue {
+	overrides := append([]ConfigLayer{}, v.Overrides...)
+	if v.Source != "" {
+		overrides = append(overrides, v.Source)
+	}
+	return EffectiveValue{Value: value, Source: layer, Overrides: overrides}
+}
+
+func mergeLayer[V any](merged map[string]EffectiveValue, values map[string]V, layer ConfigLayer) {
+	for key, value := range values {
+		merged[key] = merged[key].override(value, layer)
+	}
+}
+
+// moduleValues returns the plain merged values of a module
+func (c EffectiveConfig) moduleValues(moduleID string) map[string]any {
+	values := make(map[string]any, len(c.Modules[moduleID]))
+	for key, value := range c.Modules[moduleID] {
+		values[key] = value.Value
+	}
+	return values
+}
+
+// validateEffectiveConfig validates the merged values of every module, see
+// validateModuleValues, and the resources of the environment, see resourcePlan. Issues
+// are positioned in the document of root where possible, and prefix is put in front of
+// every message to tell which environment an issue is about.
+func (s *SolutionService) validateEffectiveConfig(effective EffectiveConfig, root *yaml.Node, prefix string) []ConfigIssue {
+	var issues []ConfigIssue
+	for _, moduleID := range sortedKeys(effective.Modules) {
+		modulePath := "spec.modules." + moduleID
+		violations, err := s.validateModuleValues(effective.SolutionID, s.moduleConfigSchema(moduleID), effective.moduleValues(moduleID))
+		if err != nil {
+			issues = append(issues, nodeIssue(root, modulePath, "%scould not validate values of %q: %s", prefix, moduleID, err.Error()))
+			continue
+		}
+		for _, violation := range violations {
+			path := modulePath + ".values"
+			if violation.Path != "" {
+				path += "." + violation.Path
+			}
+			issues = append(issues, closestNodeIssue(root, path, prefix+violation.Message))
+		}
+	}
+	for _, issue := range effective.Resources.Issues {
+		issues = append(issues, closestNodeIssue(root, issue.Path, prefix+issue.Message))
+	}
+	return issues
+}
+
+// closestNodeIssue returns an issue at path. Missing keys or keys set in another layer have
+// no position in root, the issue then points at the closest enclosing key that exists.
+func closestNodeIssue(root *yaml.Node, path string, message string) ConfigIssue {
+	issue := nodeIssue(root, path, "%s", message)
+	for parent := path; issue.Line == 0 && strings.Contains(parent, "."); {
+		parent = parent[:strings.LastIndex(parent, ".")]
+		issue.Line = nodeIssue(root, parent, "").Line
+	}
+	return issue
+}
+
+// GetEffectiveConfig returns the configuration an environment is deployed with: module
+// defaults, overridden by the solution's values, overridden by the environment's own
+// values, along with the layer every value comes from
+func (s *SolutionService) GetEffectiveConfig(solutionId string, environmentId string) (EffectiveConfig, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return EffectiveConfig{}, err
+	}
+	solutionDoc, err := s.loadSolutionDocument(solutionId)
+	if err != nil {
+		return EffectiveConfig{}, err
+	}
+	envDoc, err := s.loadEnvironmentDocument(solutionId, *env)
+	if err != nil {
+		return EffectiveConfig{}, err
+	}
+	return s.effectiveConfig(solutionId, *env, solutionDoc, envDoc), nil
+}
+
+// GetSolutionConfig returns the values shared by all environments of a solution
+func (s *SolutionService) GetSolutionConfig(solutionId string) (SolutionConfig, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	solution := s.findSolution(solutionId)
+	if solution == nil {
+		return SolutionConfig{}, fmt.Errorf("solution not found")
+	}
+
+	path := solutionConfigPath(solutionId)
+	data, saved, err := s.store.Read(path...)
+	if err != nil {
+		return SolutionConfig{}, fmt.Errorf("failed to read solution configuration: %w", err)
+	}
+	content := string(data)
+	if !saved {
+		content = defaultSolutionConfig(*solution)
+	}
+
+	_, parsed, _ := parseSolutionConfig(content, *solution)
+	config := newSolutionConfig(*solution, content, parsed)
+	config.Saved = saved
+	if saved {
+		config.UpdatedAt, _ = s.store.ModTime(path...)
+	}
+	return config, nil
+}
+
+// SaveSolutionConfig validates and stores the values shared by all environments of a
+// solution. The change is rejected if it leaves any environment with invalid values.
+func (s *SolutionService) SaveSolutionConfig(solutionId string, content string) (SolutionConfigSaveResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	solution := s.findSolution(solutionId)
+	if solution == nil {
+		return SolutionConfigSaveResult{}, fmt.Errorf("solution not found")
+	}
+
+	doc, parsed, issues := parseSolutionConfig(content, *solution)
+	if len(issues) == 0 {
+		for _, env := range solution.Environments {
+			envDoc, err := s.loadEnvironmentDocument(solutionId, env)
+			if err != nil {
+				return SolutionConfigSaveResult{}, err
+			}
+			effective := s.effectiveConfig(solutionId, env, parsed, envDoc)
+			issues = append(issues, s.validateEffectiveConfig(effective, doc.Content[0], fmt.Sprintf("%s: ", env.Name))...)
+		}
+		sort.SliceStable(issues, func(i, j int) bool {
+			return issues[i].Line < issues[j].Line
+		})
+	}
+	if len(issues) > 0 {
+		return SolutionConfigSaveResult{
+			Config: newSolutionConfig(*solution, content, parsed),
+			Issues: issues,
+		}, nil
+	}
+
+	path := solutionConfigPath(solutionId)
+	existing, saved, err := s.store.Read(path...)
+	if err != nil {
+		return SolutionConfigSaveResult{}, fmt.Errorf("failed to read solution configuration: %w", err)
+	}
+	if saved {
+		var existingDoc yaml.Node
+		if err := yaml.Unmarshal(existing, &existingDoc); err == nil {
+			doc = mergeYAML(&existingDoc, doc)
+		}
+	}
+
+	out, err := encodeYAML(doc)
+	if err != nil {
+		return SolutionConfigSaveResult{}, fmt.Errorf("failed to encode solution configuration: %w", err)
+	}
+	if err := s.store.Write([]byte(out), path...); err != nil {
+		return SolutionConfigSaveResult{}, fmt.Errorf("failed to save solution configuration: %w", err)
+	}
+
+	s.recordDeployment(solutionId, Environment{}, DeploymentEventConfig, "Solution configuration changed", nil)
+
+	config := newSolutionConfig(*solution, out, parsed)
+	config.Saved = true
+	config.UpdatedAt = time.Now()
+	return SolutionConfigSaveResult{Saved: true, Config: config, Issues: []ConfigIssue{}}, nil
+}
@@ -0,0 +1,228 @@
+package main
+
+// DependencyNode is a module in a dependency graph. Missing is set for dependencies
+// that refer to a module that isn't in the catalog.
+type DependencyNode struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Depth   int    `json:"depth"`
+	Missing bool   `json:"missing"`
+	InCycle bool   `json:"inCycle"`
+}
+
+// DependencyEdge points from a module to one of its dependencies
+type DependencyEdge struct {
+	From            string `json:"from"`
+	To              string `json:"to"`
+	RequiredVersion string `json:"requiredVersion"`
+	InCycle         bool   `json:"inCycle"`
+}
+
+type DependencyGraph struct {
+	Nodes  []DependencyNode `json:"nodes"`
+	Edges  []DependencyEdge `json:"edges"`
+	Cycles [][]string       `json:"cycles"`
+}
+
+// GetDependencyGraph returns the dependency graph of the whole catalog
+func (s *ModuleService) GetDependencyGraph() DependencyGraph {
+	all := make(map[string]int)
+	for _, module := range s.modules {
+		all[module.ID] = 0
+		for _, dep := range module.Dependencies {
+			if _, ok := all[dep.ID]; !ok {
+				all[dep.ID] = 0
+			}
+		}
+	}
+	return s.dependencySubgraph(all)
+}
+
+// GetTransitiveDependencies returns the module and everything it depends on, directly or
+// indirectly. Node depth is the shortest distance from the module.
+func (s *ModuleService) GetTransitiveDependencies(id string) DependencyGraph {
+	return s.dependencySubgraph(s.reachable(id, s.dependencyAdjacency()))
+}
+
+// GetReverseDependencies returns the module and every module that depends on it, directly
+// or indirectly, i.e. everything affected by a change to it. Edges keep pointing from
+// dependent to dependency.
+func (s *ModuleService) GetReverseDependencies(id string) DependencyGraph {
+	reverse := make(map[string][]string)
+	for from, deps := range s.dependencyAdjacency() {
+		for _, to := range deps {
+			reverse[to] = append(reverse[to], from)
+		}
+	}
+	return s.dependencySubgraph(s.reachable(id, reverse))
+}
+
+// dependencyAdjacency maps every module ID to the IDs of its direct dependencies
+func (s *ModuleService) dependencyAdjacency() map[string][]string {
+	adjacency := make(map[string][]string, len(s.modules))
+	for _, module := range s.modules {
+		deps := make([]string, 0, len(module.Dependencies))
+		for _, dep := range module.Dependencies {
+			deps = append(deps, dep.ID)
+		}
+		adjacency[module.ID] = deps
+	}
+	return adjacency
+}
+
+// reachable does a breadth first walk from id and returns the depth of every node reached
+func (s *ModuleService) reachable(id string, adjacency map[string][]string) map[string]int {
+	if s.findModule(id) == nil {
+		return map[string]int{}
+	}
+
+	depths := map[string]int{id: 0}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range adjacency[current] {
+			if _, seen := depths[next]; seen {
+				continue
+			}
+			depths[next] = depths[current] + 1
+			queue = append(queue, next)
+		}
+	}
+	return depths
+}
+
+// dependencySubgraph builds the graph restricted to the given nodes, flagging cycles
+func (s *ModuleService) dependencySubgraph(depths map[string]int) DependencyGraph {
+	graph := DependencyGraph{
+		Nodes:  []DependencyNode{},
+		Edges:  []DependencyEdge{},
+		Cycles: [][]string{},
+	}
+
+	// Cycles are found on the whole catalog so a subgraph flags the same cycles
+	inCycle := make(map[string]int)
+	for i, cycle := range findDependencyCycles(s.modules) {
+		for _, id := range cycle {
+			inCycle[id] = i
+		}
+		if _, ok := depths[cycle[0]]; ok {
+			graph.Cycles = append(graph.Cycles, cycle)
+		}
+	}
+
+	added := make(map[string]bool)
+	addNode := func(id string, name string, version string, missing bool) {
+		if added[id] {
+			return
+		}
+		added[id] = true
+		_, cyclic := inCycle[id]
+		graph.Nodes = append(graph.Nodes, DependencyNode{
+			ID:      id,
+			Name:    name,
+			Version: version,
+			Depth:   depths[id],
+			Missing: missing,
+			InCycle: cyclic,
+		})
+	}
+
+	for _, module := range s.modules {
+		if _, ok := depths[module.ID]; !ok {
+			continue
+		}
+		addNode(module.ID, module.Name, module.Version, false)
+
+		for _, dep := range module.Dependencies {
+			if _, ok := depths[dep.ID]; !ok {
+				continue
+			}
+			if s.findModule(dep.ID) == nil {
+				addNode(dep.ID, dep.Name, "", true)
+			}
+
+			fromCycle, fromOk := inCycle[module.ID]
+			toCycle, toOk := inCycle[dep.ID]
+			graph.Edges = append(graph.Edges, DependencyEdge{
+				From:            module.ID,
+				To:              dep.ID,
+				RequiredVersion: dep.Version,
+				InCycle:         fromOk && toOk && fromCycle == toCycle,
+			})
+		}
+	}
+
+	return graph
+}
+
+// findDependencyCycles returns the strongly connected components of the dependency graph
+// that contain a cycle, using Tarjan's algorithm. Modules are visited in catalog order so
+// the result is stable.
+func findDependencyCycles(modules []Module) [][]string {
+	adjacency := make(map[string][]string, len(modules))
+	for _, module := range modules {
+		for _, dep := range module.Dependencies {
+			adjacency[module.ID] = append(adjacency[module.ID], dep.ID)
+		}
+	}
+
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var strongConnect func(id string)
+	strongConnect = func(id string) {
+		indices[id] = index
+		lowlink[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+		for _, next := range adjacency[id] {
+			if next == id {
+				selfLoop = true
+			}
+			if _, visited := indices[next]; !visited {
+				strongConnect(next)
+				lowlink[id] = min(lowlink[id], lowlink[next])
+			} else if onStack[next] {
+				lowlink[id] = min(lowlink[id], indices[next])
+			}
+		}
+
+		if lowlink[id] != indices[id] {
+			return
+		}
+
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			// Reverse so the cycle reads in dependency order starting from the first visited module
+			for i, j := 0, len(component)-1; i < j; i, j = i+1, j-1 {
+				component[i], component[j] = component[j], component[i]
+			}
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, module := range modules {
+		if _, visited := indices[module.ID]; !visited {
+			strongConnect(module.ID)
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// deployerEnv selects how environments are deployed. compose runs them on this machine
+// with Docker Compose, anything else only pretends to deploy.
+const deployerEnv = "BLOCC_UI_DEPLOYER"
+
+// Deployer applies environments to the platform they run on
+type Deployer interface {
+	// Scale sets the replicas of the given components of an environment, zero stops them
+	Scale(ctx context.Context, solutionID string, env Environment, components []ComponentResourcePlan) error
+	// Apply installs or upgrades the release of a module from its rendered chart
+	Apply(ctx context.Context, solutionID string, env Environment, release ModuleManifests) error
+	// Sync re-applies the desired state of a component and restarts it
+	Sync(ctx context.Context, solutionID string, env Environment, component ComponentResourcePlan) error
+	// Teardown removes everything deployed for an environment, including its namespace
+	Teardown(ctx context.Context, solutionID string, env Environment) error
+}
+
+// componentState is what actually runs of a component. Failed is set if any of its
+// containers crashed or is unhealthy.
+type componentState struct {
+	ModuleID    string
+	ComponentID string
+	Running     int
+	Failed      bool
+}
+
+// componentObserver is implemented by deployers that can tell what actually runs and
+// read the logs of components
+type componentObserver interface {
+	Status(ctx context.Context, solutionID string, env Environment) ([]componentState, error)
+	Logs(ctx context.Context, solutionID string, env Environment, component ComponentResourcePlan, tail int) ([]LogEntry, error)
+}
+
+// newDeployer returns the deployer selected with deployerEnv. plan returns the planned
+// components of an environment.
+func newDeployer(plan func(solutionID string, env Environment) ([]ComponentResourcePlan, error)) Deployer {
+	if os.Getenv(deployerEnv) == "compose" {
+		return newComposeDeployer(plan)
+	}
+	return mockDeployer{}
+}
+
+// mockDeployDelay is how long the mock deployer pretends a single change takes
+const mockDeployDelay = 500 * time.Millisecond
+
+// mockDeployer pretends to deploy, matching the mock data the services start with
+type mockDeployer struct{}
+
+// wait pretends to do work, giving up when ctx is cancelled
+func (mockDeployer) wait(ctx context.Context) error {
+	select {
+	case <-time.After(mockDeployDelay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d mockDeployer) Scale(ctx context.Context, solutionID string, env Environment, components []ComponentResourcePlan) error {
+	for range components {
+		if err := d.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d mockDeployer) Apply(ctx context.Context, solutionID string, env Environment, release ModuleManifests) error {
+	return d.wait(ctx)
+}
+
+func (d mockDeployer) Sync(ctx context.Context, solutionID string, env Environment, component ComponentResourcePlan) error {
+	return d.wait(ctx)
+}
+
+func (d mockDeployer) Teardown(ctx context.Context, solutionID string, env Environment) error {
+	return d.wait(ctx)
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+)
+
+// DeploymentEventKind is what caused a deployment. Upgrades, downgrades and uninstalls
+// only happen as part of promotions and rollbacks, so they don't have kinds of their own,
+// see DeploymentEvent.Changes.
+type DeploymentEventKind string
+
+const (
+	DeploymentEventInstall   DeploymentEventKind = "install"
+	DeploymentEventConfig    DeploymentEventKind = "config"
+	DeploymentEventPromotion DeploymentEventKind = "promotion"
+	DeploymentEventRollback  DeploymentEventKind = "rollback"
+	DeploymentEventStart     DeploymentEventKind = "start"
+	DeploymentEventStop      DeploymentEventKind = "stop"
+	DeploymentEventDelete    DeploymentEventKind = "delete"
+	DeploymentEventSync      DeploymentEventKind = "sync"
+)
+
+// ModuleVersionChange is a module whose version changed in a deployment. Before is empty
+// for an install, After for an uninstall.
+type ModuleVersionChange struct {
+	ModuleID string `json:"moduleId"`
+	Before   string `json:"before"`
+	After    string `json:"after"`
+}
+
+// DeploymentEvent is an entry in the deployment history of a solution. Modules and
+// ConfigRevision capture the environment as it was right after the event, so it can be
+// restored later. Events without an EnvironmentID apply to the solution as a whole, such
+// as changes to the solution's configuration. Changes lists the modules the event
+// installed, upgraded, downgraded or uninstalled.
+type DeploymentEvent struct {
+	ID              string                `json:"id"`
+	SolutionID      string                `json:"solutionId"`
+	EnvironmentID   string                `json:"environmentId"`
+	EnvironmentName string                `json:"environmentName"`
+	Kind            DeploymentEventKind   `json:"kind"`
+	Message         string                `json:"message"`
+	Changes         []ModuleVersionChange `json:"changes"`
+	Modules         []EnvironmentModule   `json:"modules"`
+	ConfigRevision  int                   `json:"configRevision"`
+	Author          string                `json:"author"`
+	CreatedAt       time.Time             `json:"createdAt" ts_type:"string"`
+}
+
+// deploymentHistoryPath is a JSON lines file that events are only ever appended to
+func deploymentHistoryPath(solutionID string) []string {
+	return []string{"solutions", solutionID, "deployments.jsonl"}
+}
+
+// recordDeployment appends an event for env to the deployment history. Pass a zero
+// Environment for events that concern the whole solution. The change being recorded has
+// already been applied at this point, so a failure is logged rather than returned.
+func (s *SolutionService) recordDeployment(solutionId string, env Environment, kind DeploymentEventKind, message string, changes []ModuleVersionChange) {
+	if err := s.appendDeployment(solutionId, env, kind, message, changes); err != nil {
+		log.Printf("failed to record %s of %s/%s: %v", kind, solutionId, env.ID, err)
+	}
+}
+
+func (s *SolutionService) appendDeployment(solutionId string, env Environment, kind DeploymentEventKind, message string, changes []ModuleVersionChange) error {
+	event := DeploymentEvent{
+		ID:              newID(),
+		SolutionID:      solutionId,
+		EnvironmentID:   env.ID,
+		EnvironmentName: env.Name,
+		Kind:            kind,
+		Message:         message,
+		Changes:         append([]ModuleVersionChange{}, changes...),
+		Modules:         append([]EnvironmentModule{}, env.Modules...),
+		Author:          currentAuthor(),
+		CreatedAt:       time.Now(),
+	}
+	if env.ID != "" {
+		revisions, err := s.loadConfigHistory(solutionId, env.ID)
+		if err != nil {
+			return err
+		}
+		if len(revisions) > 0 {
+			event.ConfigRevision = revisions[len(revisions)-1].Revision
+		}
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Append(append(data, '\n'), deploymentHistoryPath(solutionId)...); err != nil {
+		return fmt.Errorf("failed to record deployment: %w", err)
+	}
+	return nil
+}
+
+// applyDeployment is the common path for changing the modules and configuration of an
+// environment: content is validated and stored as a new configuration revision, then the
+// modules are replaced and the change is recorded in the deployment history. Nothing is
+// changed if the configuration is invalid, the issues are returned in the result.
+func (s *SolutionService) applyDeployment(solutionId string, env *Environment, modules []EnvironmentModule, content string, revision ConfigRevision, kind DeploymentEventKind, message string) (ConfigSaveResult, error) {
+	deployed := env.clone()
+	deployed.Modules = modules
+	result, err := s.saveEnvironmentConfig(solutionId, &deployed, content, revision, false)
+	if err != nil || !result.Saved {
+		return result, err
+	}
+
+	changes := versionChanges(env.Modules, modules)
+	env.Modules = append([]EnvironmentModule{}, modules...)
+	env.LastDeployed = time.Now()
+	if solution := s.findSolution(solutionId); solution != nil {
+		solution.UpdatedAt = time.Now()
+	}
+	s.recordDeployment(solutionId, *env, kind, message, changes)
+	return result, nil
+}
+
+// loadDeploymentHistory returns the deployment events of a solution, oldest first. An empty
+// environmentId returns the events of all environments.
+func (s *SolutionService) loadDeploymentHistory(solutionId string, environmentId string) ([]DeploymentEvent, error) {
+	events := []DeploymentEvent{}
+	data, ok, err := s.store.Read(deploymentHistoryPath(solutionId)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployment history: %w", err)
+	}
+	if !ok {
+		return events, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var event DeploymentEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("deployment history is corrupt at line %d: %w", line, err)
+		}
+		if environmentId == "" || event.EnvironmentID == environmentId {
+			events = append(events, event)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// GetDeploymentHistory returns the installs, upgrades, configuration changes, promotions
+// and lifecycle changes of a solution's environments, newest first. An empty environmentId
+// returns the history of the whole solution.
+func (s *SolutionService) GetDeploymentHistory(solutionId string, environmentId string) ([]DeploymentEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.findSolution(solutionId) == nil {
+		return nil, fmt.Errorf("solution not found")
+	}
+	events, err := s.loadDeploymentHistory(solutionId, environmentId)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(events)
+	return events, nil
+}
+
+// ExportDeploymentHistory returns the deployment history as a JSON document, oldest first,
+// for archiving or audits. An empty environmentId exports the whole solution.
+func (s *SolutionService) ExportDeploymentHistory(solutionId string, environmentId string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.findSolution(solutionId) == nil {
+		return "", fmt.Errorf("solution not found")
+	}
+	events, err := s.loadDeploymentHistory(solutionId, environmentId)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// versionChanges lists the modules whose version differs between two module lists
+func versionChanges(before []EnvironmentModule, after []EnvironmentModule) []ModuleVersionChange {
+	versions := func(modules []EnvironmentModule) map[string]string {
+		m := make(map[string]string, len(modules))
+		for _, module := range modules {
+			m[module.ModuleID] = module.Version
+		}
+		return m
+	}
+	from, to := versions(before), versions(after)
+
+	changes := []ModuleVersionChange{}
+	for _, module := range after {
+		if from[module.ModuleID] != module.Version {
+			changes = append(changes, ModuleVersionChange{ModuleID: module.ModuleID, Before: from[module.ModuleID], After: module.Version})
+		}
+	}
+	for _, module := range before {
+		if _, ok := to[module.ModuleID]; !ok {
+			changes = append(changes, ModuleVersionChange{ModuleID: module.ModuleID, Before: module.Version})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type DriftKind string
+
+const (
+	// DriftMissing is a module the solution declares that isn't installed
+	DriftMissing DriftKind = "missing"
+	// DriftUndeclared is an installed module the solution doesn't declare
+	DriftUndeclared DriftKind = "undeclared"
+	// DriftVersion is a module installed in another version than the solution declares
+	DriftVersion DriftKind = "version"
+	// DriftConfig is a module whose values differ from the solution's configuration
+	DriftConfig DriftKind = "config"
+)
+
+// ModuleEnvironmentState is a cell of the comparison matrix: a module in one environment.
+// ConfigDrift lists the configuration paths where the environment deviates from the baseline.
+type ModuleEnvironmentState struct {
+	EnvironmentID string            `json:"environmentId"`
+	Installed     bool              `json:"installed"`
+	Version       string            `json:"version"`
+	Status        EnvironmentStatus `json:"status"`
+	Drift         []DriftKind       `json:"drift"`
+	ConfigDrift   []string          `json:"configDrift"`
+}
+
+// ModuleComparison is a row of the comparison matrix. BaselineVersion is the version in
+// Solution.Modules, empty if the solution doesn't declare the module.
+type ModuleComparison struct {
+	ModuleID        string                   `json:"moduleId"`
+	Name            string                   `json:"name"`
+	Declared        bool                     `json:"declared"`
+	BaselineVersion string                   `json:"baselineVersion"`
+	Environments    []ModuleEnvironmentState `json:"environments"`
+	Drifted         bool                     `json:"drifted"`
+}
+
+// ConfigComparison is an effective configuration value that isn't the same everywhere.
+// Values holds the value per environment ID, environments without the value are left out.
+// Baseline is what the solution's configuration and the module defaults give.
+type ConfigComparison struct {
+	Path     string         `json:"path"`
+	Baseline any            `json:"baseline"`
+	Values   map[string]any `json:"values"`
+	Secret   bool           `json:"secret"`
+}
+
+// EnvironmentSummary is a column of the comparison matrix. ConfigDrift lists the paths
+// outside of module values, such as the resource profile, that deviate from the baseline.
+type EnvironmentSummary struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Stage       EnvironmentStage  `json:"stage"`
+	Status      EnvironmentStatus `json:"status"`
+	ConfigDrift []string          `json:"configDrift"`
+	DriftCount  int               `json:"driftCount"`
+}
+
+// EnvironmentComparison compares all environments of a solution against each other and
+// against the solution's declared modules and configuration
+type EnvironmentComparison struct {
+	SolutionID   string               `json:"solutionId"`
+	Environments []EnvironmentSummary `json:"environments"`
+	Modules      []ModuleComparison   `json:"modules"`
+	Config       []ConfigComparison   `json:"config"`
+	DriftCount   int                  `json:"driftCount"`
+}
+
+// flattenEffectiveConfig returns the values of an effective configuration by dotted path
+func flattenEffectiveConfig(effective EffectiveConfig) map[string]any {
+	values := map[string]any{"resourceProfile": effective.ResourceProfile.Value}
+	for key, value := range effective.Global {
+		values["global."+key] = value.Value
+	}
+	for moduleID := range effective.Modules {
+		for key, value := range effective.moduleValues(moduleID) {
+			flattenValues("modules."+moduleID+"."+key, value, values)
+		}
+	}
+	return values
+}
+
+// CompareEnvironments builds a matrix of module versions, statuses and configuration across
+// all environments of a solution. Solution.Modules and the solution's configuration are
+// the baseline, anything that deviates from it is reported as drift.
+func (s *SolutionService) CompareEnvironments(solutionId string) (EnvironmentComparison, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	solution := s.findSolution(solutionId)
+	if solution == nil {
+		return EnvironmentComparison{}, fmt.Errorf("solution not found")
+	}
+	solutionDoc, err := s.loadSolutionDocument(solutionId)
+	if err != nil {
+		return EnvironmentComparison{}, err
+	}
+
+	comparison := EnvironmentComparison{
+		SolutionID:   solutionId,
+		Environments: []EnvironmentSummary{},
+		Modules:      []ModuleComparison{},
+		Config:       []ConfigComparison{},
+	}
+
+	// Effective values per environment, and what they would be without environment overrides
+	values := make(map[string]map[string]any)
+	baseline := make(map[string]any)
+	for _, env := range solution.Environments {
+		envDoc, err := s.loadEnvironmentDocument(solutionId, env)
+		if err != nil {
+			return EnvironmentComparison{}, err
+		}
+		values[env.ID] = flattenEffectiveConfig(s.effectiveConfig(solutionId, env, solutionDoc, envDoc))
+		for path, value := range flattenEffectiveConfig(s.effectiveConfig(solutionId, env, solutionDoc, configDocument{})) {
+			baseline[path] = value
+		}
+	}
+
+	paths := make(map[string]bool)
+	for _, envValues := range values {
+		for path := range envValues {
+			paths[path] = true
+		}
+	}
+	configDrift := make(map[string][]string)
+	for _, path := range sortedKeys(paths) {
+		row := ConfigComparison{Path: path, Baseline: baseline[path], Values: make(map[string]any)}
+		differs := false
+		for _, env := range solution.Environments {
+			value, ok := values[env.ID][path]
+			if !ok {
+				continue
+			}
+			row.Values[env.ID] = value
+			if _, isRef := value.(SecretRef); isRef {
+				row.Secret = true
+			}
+			if !reflect.DeepEqual(value, baseline[path]) {
+				differs = true
+				configDrift[env.ID] = append(configDrift[env.ID], path)
+			}
+		}
+		if differs {
+			comparison.Config = append(comparison.Config, row)
+		}
+	}
+
+	// Rows for the declared modules first, then for anything installed on top
+	var moduleIDs []string
+	declared := make(map[string]string)
+	for _, module := range solution.Modules {
+		declared[module.ModuleID] = module.Version
+		moduleIDs = append(moduleIDs, module.ModuleID)
+	}
+	for _, env := range solution.Environments {
+		for _, module := range env.Modules {
+			if !slices.Contains(moduleIDs, module.ModuleID) {
+				moduleIDs = append(moduleIDs, module.ModuleID)
+			}
+		}
+	}
+
+	driftCount := make(map[string]int)
+	for _, moduleID := range moduleIDs {
+		baselineVersion, isDeclared := declared[moduleID]
+		row := ModuleComparison{
+			ModuleID:        moduleID,
+			Name:            moduleID,
+			Declared:        isDeclared,
+			BaselineVersion: baselineVersion,
+			Environments:    []ModuleEnvironmentState{},
+		}
+		if s.modules != nil {
+			if module := s.modules.findModule(moduleID); module != nil {
+				row.Name = module.Name
+			}
+		}
+
+		for _, env := range solution.Environments {
+			state := ModuleEnvironmentState{EnvironmentID: env.ID, Drift: []DriftKind{}, ConfigDrift: []string{}}
+			for _, module := range env.Modules {
+				if module.ModuleID == moduleID {
+					state.Installed = true
+					state.Version = module.Version
+					state.Status = module.Status
+				}
+			}
+
+			switch {
+			case !state.Installed && isDeclared:
+				state.Drift = append(state.Drift, DriftMissing)
+			case state.Installed && !isDeclared:
+				state.Drift = append(state.Drift, DriftUndeclared)
+			case state.Installed && state.Version != baselineVersion:
+				state.Drift = append(state.Drift, DriftVersion)
+			}
+			for _, path := range configDrift[env.ID] {
+				if strings.HasPrefix(path, "modules."+moduleID+".") {
+					state.ConfigDrift = append(state.ConfigDrift, path)
+				}
+			}
+			if len(state.ConfigDrift) > 0 {
+				state.Drift = append(state.Drift, DriftConfig)
+			}
+
+			if len(state.Drift) > 0 {
+				row.Drifted = true
+				driftCount[env.ID] += len(state.Drift)
+			}
+			row.Environments = append(row.Environments, state)
+		}
+		comparison.Modules = append(comparison.Modules, row)
+	}
+
+	for _, env := range solution.Environments {
+		summary := EnvironmentSummary{
+			ID:          env.ID,
+			Name:        env.Name,
+			Stage:       environmentStage(env),
+			Status:      env.Status,
+			ConfigDrift: []string{},
+			DriftCount:  driftCount[env.ID],
+		}
+		for _, path := range configDrift[env.ID] {
+			if !strings.HasPrefix(path, "modules.") {
+				summary.ConfigDrift = append(summary.ConfigDrift, path)
+				summary.DriftCount++
+			}
+		}
+		comparison.Environments = append(comparison.Environments, summary)
+		comparison.DriftCount += summary.DriftCount
+	}
+	return comparison, nil
+}
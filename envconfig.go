@@ -0,0 +1,526 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+const environmentConfigAPIVersion = "environments.blocc.dev/v1beta1"
+
+// defaultResourceProfile and defaultDomainSuffix match the defaults of the settings page
+const (
+	defaultResourceProfile = "small"
+	defaultDomainSuffix    = "services.stacc.dev"
+)
+
+// EnvironmentConfig is the configuration of an environment. YAML is the document as
+// stored, the other fields are the parsed values.
+type EnvironmentConfig struct {
+	SolutionID      string                    `json:"solutionId"`
+	EnvironmentID   string                    `json:"environmentId"`
+	YAML            string                    `json:"yaml"`
+	ResourceProfile string                    `json:"resourceProfile"`
+	Global          map[string]string         `json:"global"`
+	Modules         map[string]map[string]any `json:"modules"`
+	Saved           bool                      `json:"saved"`
+	UpdatedAt       time.Time                 `json:"updatedAt" ts_type:"string"`
+	Revision        int                       `json:"revision"`
+}
+
+// ConfigIssue is a problem found in an environment configuration. Path is the dotted
+// path of the offending key, Line is 0 when the position is unknown.
+type ConfigIssue struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ConfigSaveResult tells the frontend whether a configuration was saved and if not, why.
+// OperationID tracks deploying a saved configuration, if it's deployed.
+type ConfigSaveResult struct {
+	Saved       bool              `json:"saved"`
+	Config      EnvironmentConfig `json:"config"`
+	Issues      []ConfigIssue     `json:"issues"`
+	OperationID string            `json:"operationId"`
+}
+
+// configDocument is the schema of the configuration documents of environments and solutions
+type configDocument struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		ResourceProfile string `yaml:"resourceProfile"`
+		Global          struct {
+			Values     map[string]string `yaml:"values"`
+			ValuesFrom map[string]any    `yaml:"valuesFrom"`
+		} `yaml:"global"`
+		Modules map[string]struct {
+			Values    map[string]any                       `yaml:"values"`
+			Resources map[string]ComponentResourceOverride `yaml:"resources"`
+		} `yaml:"modules"`
+		Quota map[string]string `yaml:"quota"`
+	} `yaml:"spec"`
+}
+
+// environmentKind returns the document kind for an environment
+func environmentKind(env Environment) string {
+	if isDevelopmentEnvironment(env) {
+		return "Development"
+	}
+	return "Environment"
+}
+
+// defaultEnvironmentConfig returns the configuration of an environment that was never saved.
+// It overrides nothing, so the environment gets the solution's values and module defaults.
+func defaultEnvironmentConfig(env Environment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "apiVersion: %s\n", environmentConfigAPIVersion)
+	fmt.Fprintf(&b, "kind: %s\n", environmentKind(env))
+	b.WriteString("metadata:\n")
+	fmt.Fprintf(&b, "  name: %q\n", env.Name)
+	b.WriteString("spec:\n")
+	b.WriteString("  global:\n")
+	b.WriteString("    values: {}\n")
+	b.WriteString("    valuesFrom: {}\n")
+	writeModuleValues(&b, env.Modules)
+	return b.String()
+}
+
+// writeModuleValues writes an empty values section for each module
+func writeModuleValues(b *strings.Builder, modules []EnvironmentModule) {
+	if len(modules) == 0 {
+		b.WriteString("  modules: {}\n")
+		return
+	}
+	b.WriteString("  modules:\n")
+	for _, module := range modules {
+		fmt.Fprintf(b, "    %s:\n", module.ModuleID)
+		b.WriteString("      values: {}\n")
+	}
+}
+
+// parseEnvironmentConfig parses and validates the configuration document of env
+func parseEnvironmentConfig(content string, env Environment) (*yaml.Node, configDocument, []ConfigIssue) {
+	installed := make(map[string]bool, len(env.Modules))
+	for _, module := range env.Modules {
+		installed[module.ModuleID] = true
+	}
+	return parseConfigDocument(content, env.Name, installed, fmt.Sprintf("environment %q", env.Name))
+}
+
+// parseConfigDocument parses a configuration document and checks its structure. Only the
+// modules in configurable may have values, owner names the document in messages. It
+// returns the document node for round-tripping along with the decoded values. Module
+// values are checked against their schemas once all layers are merged, see validateEffectiveConfig.
+func parseConfigDocument(content string, name string, configurable map[string]bool, owner string) (*yaml.Node, configDocument, []ConfigIssue) {
+	var config configDocument
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, config, []ConfigIssue{yamlErrorIssue(err)}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, config, []ConfigIssue{{Message: "configuration must be a YAML mapping"}}
+	}
+
+	// Decode strictly so misspelled keys are reported rather than silently ignored
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, config, []ConfigIssue{yamlErrorIssue(err)}
+		}
+		issues := make([]ConfigIssue, 0, len(typeErr.Errors))
+		for _, msg := range typeErr.Errors {
+			issues = append(issues, yamlErrorIssue(errors.New(msg)))
+		}
+		return nil, config, issues
+	}
+
+	var issues []ConfigIssue
+	root := doc.Content[0]
+	if config.APIVersion != environmentConfigAPIVersion {
+		issues = append(issues, nodeIssue(root, "apiVersion", "apiVersion must be %q", environmentConfigAPIVersion))
+	}
+	if config.Kind == "" {
+		issues = append(issues, nodeIssue(root, "kind", "kind is required"))
+	}
+	if node := findNode(root, "spec.resourceProfile"); node != nil && strings.TrimSpace(config.Spec.ResourceProfile) == "" {
+		issues = append(issues, nodeIssue(root, "spec.resourceProfile", "resourceProfile must not be empty, remove it to inherit the profile"))
+	}
+
+	for _, moduleID := range sortedKeys(config.Spec.Modules) {
+		if !configurable[moduleID] {
+			issues = append(issues, nodeIssue(root, "spec.modules."+moduleID, "module %q is not installed in %s", moduleID, owner))
+		}
+	}
+
+	// The document is named after its owner, keep it in sync if that was renamed
+	if config.Metadata.Name != name {
+		setMappingValue(root, name, "metadata", "name")
+		config.Metadata.Name = name
+	}
+
+	return &doc, config, issues
+}
+
+// encodeYAML renders a document node with the two space indentation used by the settings page
+func encodeYAML(doc *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// mergeYAML applies incoming onto existing so that comments and key order of the stored
+// document survive a save from the form view, which regenerates the document without them.
+// Values always come from incoming. Keys that only exist in existing are dropped, keys
+// that are new in incoming are appended in their incoming order.
+func mergeYAML(existing, incoming *yaml.Node) *yaml.Node {
+	if existing == nil || existing.Kind != incoming.Kind {
+		return incoming
+	}
+
+	keepComments(existing, incoming)
+
+	switch incoming.Kind {
+	case yaml.DocumentNode:
+		if len(existing.Content) == 1 && len(incoming.Content) == 1 {
+			incoming.Content[0] = mergeYAML(existing.Content[0], incoming.Content[0])
+		}
+	case yaml.MappingNode:
+		incomingValues := make(map[string]*yaml.Node)
+		incomingKeys := make(map[string]*yaml.Node)
+		var order []string
+		for i := 0; i+1 < len(incoming.Content); i += 2 {
+			key := incoming.Content[i].Value
+			incomingKeys[key] = incoming.Content[i]
+			incomingValues[key] = incoming.Content[i+1]
+			order = append(order, key)
+		}
+
+		var content []*yaml.Node
+		merged := make(map[string]bool)
+		for i := 0; i+1 < len(existing.Content); i += 2 {
+			key := existing.Content[i].Value
+			value, ok := incomingValues[key]
+			if !ok {
+				continue
+			}
+			keepComments(existing.Content[i], incomingKeys[key])
+			content = append(content, incomingKeys[key], mergeYAML(existing.Content[i+1], value))
+			merged[key] = true
+		}
+		for _, key := range order {
+			if !merged[key] {
+				content = append(content, incomingKeys[key], incomingValues[key])
+			}
+		}
+		incoming.Content = content
+	case yaml.SequenceNode:
+		for i := range incoming.Content {
+			if i < len(existing.Content) {
+				incoming.Content[i] = mergeYAML(existing.Content[i], incoming.Content[i])
+			}
+		}
+	}
+
+	// An empty mapping written as {} stays inline, a filled one goes back to block style
+	if incoming.Kind == yaml.MappingNode && len(incoming.Content) > 0 {
+		incoming.Style &^= yaml.FlowStyle
+	}
+	return incoming
+}
+
+// keepComments copies the comments of existing to incoming where incoming has none
+func keepComments(existing, incoming *yaml.Node) {
+	if incoming.HeadComment == "" {
+		incoming.HeadComment = existing.HeadComment
+	}
+	if incoming.LineComment == "" {
+		incoming.LineComment = existing.LineComment
+	}
+	if incoming.FootComment == "" {
+		incoming.FootComment = existing.FootComment
+	}
+}
+
+// setMappingValue sets the scalar at path below a mapping node, creating mappings on the way
+func setMappingValue(node *yaml.Node, value string, path ...string) {
+	for i, key := range path {
+		var child *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == key {
+				child = node.Content[j+1]
+				break
+			}
+		}
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if i == len(path)-1 {
+				child = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+		}
+		if i == len(path)-1 {
+			child.Kind = yaml.ScalarNode
+			child.Tag = "!!str"
+			child.Value = value
+			return
+		}
+		node = child
+	}
+}
+
+// setMappingNode sets the node at path below a mapping node, creating mappings on the way.
+// Comments of a node that is replaced are carried over, see mergeYAML.
+func setMappingNode(node *yaml.Node, value *yaml.Node, path ...string) {
+	for i, key := range path {
+		index := -1
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == key {
+				index = j + 1
+				break
+			}
+		}
+		if index < 0 {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+			index = len(node.Content) - 1
+		}
+		if i == len(path)-1 {
+			node.Content[index] = mergeYAML(node.Content[index], value)
+			return
+		}
+		if node.Content[index].Kind != yaml.MappingNode {
+			node.Content[index] = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		node = node.Content[index]
+	}
+}
+
+// findNode returns the node at a dotted path below a mapping node, or nil
+func findNode(node *yaml.Node, path string) *yaml.Node {
+	for _, key := range strings.Split(path, ".") {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		node = next
+	}
+	return node
+}
+
+func nodeIssue(root *yaml.Node, path string, format string, args ...any) ConfigIssue {
+	issue := ConfigIssue{Path: path, Message: fmt.Sprintf(format, args...)}
+	if node := findNode(root, path); node != nil {
+		issue.Line = node.Line
+	}
+	return issue
+}
+
+// yamlErrorIssue turns a yaml.v3 error such as "yaml: line 3: ..." into an issue
+func yamlErrorIssue(err error) ConfigIssue {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	issue := ConfigIssue{Message: msg}
+	var line int
+	if n, _ := fmt.Sscanf(msg, "line %d:", &line); n == 1 {
+		issue.Line = line
+		issue.Message = strings.TrimSpace(strings.SplitN(msg, ":", 2)[1])
+	}
+	// The decoder names the Go type the field is missing from, which means nothing to the user
+	if match := unknownFieldError.FindStringSubmatch(issue.Message); match != nil {
+		issue.Message = fmt.Sprintf("unknown field %q", match[1])
+	}
+	return issue
+}
+
+var unknownFieldError = regexp.MustCompile(`^field (\S+) not found in type `)
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// newEnvironmentConfig builds the response for a parsed configuration document
+func newEnvironmentConfig(solutionID string, env Environment, content string, config configDocument) EnvironmentConfig {
+	modules := make(map[string]map[string]any, len(config.Spec.Modules))
+	for moduleID, module := range config.Spec.Modules {
+		values := module.Values
+		if values == nil {
+			values = map[string]any{}
+		}
+		modules[moduleID] = values
+	}
+	global := config.Spec.Global.Values
+	if global == nil {
+		global = map[string]string{}
+	}
+	return EnvironmentConfig{
+		SolutionID:      solutionID,
+		EnvironmentID:   env.ID,
+		YAML:            content,
+		ResourceProfile: config.Spec.ResourceProfile,
+		Global:          global,
+		Modules:         modules,
+	}
+}
+
+// environmentConfigPath is where the configuration of an environment is stored
+func environmentConfigPath(solutionID string, environmentID string) []string {
+	return []string{"solutions", solutionID, "environments", environmentID, "config.yaml"}
+}
+
+// moduleConfigSchema returns the configuration schema of a module in the catalog, if any
+func (s *SolutionService) moduleConfigSchema(moduleID string) *jsonschema.Schema {
+	if s.modules == nil {
+		return nil
+	}
+	return s.modules.moduleConfigSchema(moduleID)
+}
+
+// GetEnvironmentConfig returns the stored configuration of an environment, or the
+// default configuration if it was never saved
+func (s *SolutionService) GetEnvironmentConfig(solutionId string, environmentId string) (EnvironmentConfig, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return EnvironmentConfig{}, err
+	}
+
+	path := environmentConfigPath(solutionId, environmentId)
+	data, saved, err := s.store.Read(path...)
+	if err != nil {
+		return EnvironmentConfig{}, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	content := string(data)
+	if !saved {
+		content = defaultEnvironmentConfig(*env)
+	}
+
+	// A stored configuration that no longer validates, e.g. because a module was
+	// uninstalled since, is still returned so it can be fixed in the editor
+	_, parsed, _ := parseEnvironmentConfig(content, *env)
+	config := newEnvironmentConfig(solutionId, *env, content, parsed)
+	config.Saved = saved
+	if saved {
+		config.UpdatedAt, _ = s.store.ModTime(path...)
+	}
+	if revisions, err := s.loadConfigHistory(solutionId, environmentId); err == nil && len(revisions) > 0 {
+		config.Revision = revisions[len(revisions)-1].Revision
+	}
+	return config, nil
+}
+
+// SaveEnvironmentConfig validates and stores the configuration of an environment and
+// deploys it. When the configuration is invalid nothing is stored and the issues are
+// returned in the result. Comments and key order of the stored document are kept, see
+// mergeYAML. Every save is recorded as a revision with the given message, see
+// GetConfigHistory. The deployment runs in the background, the operation ID in the
+// result tracks it.
+func (s *SolutionService) SaveEnvironmentConfig(solutionId string, environmentId string, content string, message string) (ConfigSaveResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return ConfigSaveResult{}, err
+	}
+	if isTransitional(env.Status) {
+		return ConfigSaveResult{}, fmt.Errorf("environment is %s, wait for it to finish", env.Status)
+	}
+	result, err := s.saveEnvironmentConfig(solutionId, env, content, ConfigRevision{Message: message}, true)
+	if err != nil || !result.Saved {
+		return result, err
+	}
+	if message == "" {
+		message = "Configuration changed"
+	}
+	s.recordDeployment(solutionId, *env, DeploymentEventConfig, message, nil)
+
+	result.OperationID, err = s.rollOut(solutionId, env, nil, "config", fmt.Sprintf("Deploy configuration of %s", env.Name))
+	return result, err
+}
+
+// saveEnvironmentConfig validates and stores content as the configuration of env and
+// records it as a new revision. With merge set, comments and key order of the stored
+// document are carried over, otherwise content is stored as is.
+func (s *SolutionService) saveEnvironmentConfig(solutionId string, env *Environment, content string, revision ConfigRevision, merge bool) (ConfigSaveResult, error) {
+	doc, parsed, issues := parseEnvironmentConfig(content, *env)
+	if len(issues) == 0 {
+		// Values only have to be complete once merged with the solution's values and module defaults
+		solutionDoc, err := s.loadSolutionDocument(solutionId)
+		if err != nil {
+			return ConfigSaveResult{}, err
+		}
+		effective := s.effectiveConfig(solutionId, *env, solutionDoc, parsed)
+		issues = s.validateEffectiveConfig(effective, doc.Content[0], "")
+	}
+	if len(issues) > 0 {
+		return ConfigSaveResult{
+			Config: newEnvironmentConfig(solutionId, *env, content, parsed),
+			Issues: issues,
+		}, nil
+	}
+
+	path := environmentConfigPath(solutionId, env.ID)
+	if merge {
+		existing, saved, err := s.store.Read(path...)
+		if err != nil {
+			return ConfigSaveResult{}, fmt.Errorf("failed to read configuration: %w", err)
+		}
+		if saved {
+			var existingDoc yaml.Node
+			if err := yaml.Unmarshal(existing, &existingDoc); err == nil {
+				doc = mergeYAML(&existingDoc, doc)
+			}
+		}
+	}
+
+	out, err := encodeYAML(doc)
+	if err != nil {
+		return ConfigSaveResult{}, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	if err := s.store.Write([]byte(out), path...); err != nil {
+		return ConfigSaveResult{}, fmt.Errorf("failed to save configuration: %w", err)
+	}
+	recorded, err := s.recordConfigRevision(solutionId, env.ID, out, revision)
+	if err != nil {
+		return ConfigSaveResult{}, err
+	}
+
+	config := newEnvironmentConfig(solutionId, *env, out, parsed)
+	config.Saved = true
+	config.UpdatedAt = recorded.CreatedAt
+	config.Revision = recorded.Revision
+	return ConfigSaveResult{Saved: true, Config: config, Issues: []ConfigIssue{}}, nil
+}
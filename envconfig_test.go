@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestSaveEnvironmentConfigKeepsComments saves a commented configuration, then the same
+// configuration from the form view, which has neither comments nor the original key order
+func TestSaveEnvironmentConfigKeepsComments(t *testing.T) {
+	s := newTestSolutionService(t)
+	const solutionId, environmentId = "demo-solution", "dev-1"
+	save := func(content string, message string) {
+		t.Helper()
+		result, err := s.SaveEnvironmentConfig(solutionId, environmentId, content, message)
+		if err != nil || !result.Saved {
+			t.Fatalf("SaveEnvironmentConfig: %v %v", err, result.Issues)
+		}
+		waitForOperations(t, s)
+	}
+
+	save(`# Ask the platform team before changing the development settings
+apiVersion: environments.blocc.dev/v1beta1
+kind: Development
+metadata:
+  name: "Development 1"
+spec:
+  global:
+    values: {}
+    valuesFrom: {}
+  modules:
+    # Flow runs the processes
+    flow:
+      values: {}
+    control-panel:
+      values:
+        theme: light # matches the portal
+`, "Comments")
+
+	// The form view sends the values it edited, its keys in alphabetical order
+	config, err := s.GetEnvironmentConfig(solutionId, environmentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var form map[string]any
+	if err := yaml.Unmarshal([]byte(config.YAML), &form); err != nil {
+		t.Fatal(err)
+	}
+	form["spec"].(map[string]any)["modules"].(map[string]any)["control-panel"] = map[string]any{
+		"values": map[string]any{"theme": "dark"},
+	}
+	content, err := yaml.Marshal(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "#") || strings.Index(string(content), "flow:") < strings.Index(string(content), "control-panel:") {
+		t.Fatalf("form view content has comments or the stored key order:\n%s", content)
+	}
+	save(string(content), "Dark theme")
+
+	config, err = s.GetEnvironmentConfig(solutionId, environmentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Ask the platform team before changing the development settings\n",
+		"# Flow runs the processes\n",
+		"theme: dark # matches the portal\n",
+	} {
+		if !strings.Contains(config.YAML, want) {
+			t.Errorf("reloaded configuration doesn't contain %q:\n%s", want, config.YAML)
+		}
+	}
+	last := -1
+	for _, key := range []string{"apiVersion:", "kind:", "metadata:", "spec:", "global:", "modules:", "flow:", "control-panel:"} {
+		i := strings.Index(config.YAML, key)
+		if i <= last {
+			t.Errorf("%s isn't in its stored place:\n%s", key, config.YAML)
+		}
+		last = max(last, i)
+	}
+}
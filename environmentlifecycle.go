@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// deployTimeout bounds how long a single call to the deployer may take
+const deployTimeout = 10 * time.Minute
+
+type UpdateEnvironmentRequest struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// isTransitional reports whether an environment is in the middle of a lifecycle operation
+func isTransitional(status EnvironmentStatus) bool {
+	switch status {
+	case EnvironmentStatusStarting, EnvironmentStatusStopping, EnvironmentStatusDeleting, EnvironmentStatusSyncing, EnvironmentStatusDeploying:
+		return true
+	}
+	return false
+}
+
+// beginTransition moves an environment into a transitional status if its current status
+// is one of from. Only one lifecycle operation can run on an environment at a time, a
+// second one fails until the first finishes. It returns a copy of the environment as it
+// was before the transition.
+func (s *SolutionService) beginTransition(solutionId string, environmentId string, to EnvironmentStatus, from ...EnvironmentStatus) (Environment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transition(solutionId, environmentId, to, from...)
+}
+
+// beginPlannedTransition is beginTransition for operations on the components of an
+// environment. pick chooses the components from its plan, nil takes all of them, and is
+// called in the same lock as the transition so the plan can't change in between. Nothing
+// changes if pick fails.
+func (s *SolutionService) beginPlannedTransition(solutionId string, environmentId string, to EnvironmentStatus, pick func(plan []ComponentResourcePlan) ([]ComponentResourcePlan, error), from ...EnvironmentStatus) (Environment, []ComponentResourcePlan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return Environment{}, nil, err
+	}
+	plan, err := s.plannedComponents(solutionId, *env)
+	if err != nil {
+		return Environment{}, nil, err
+	}
+	if pick != nil {
+		if plan, err = pick(plan); err != nil {
+			return Environment{}, nil, err
+		}
+	}
+	before, err := s.transition(solutionId, environmentId, to, from...)
+	if err != nil {
+		return Environment{}, nil, err
+	}
+	return before, plan, nil
+}
+
+// transition is beginTransition for callers that already hold s.mu
+func (s *SolutionService) transition(solutionId string, environmentId string, to EnvironmentStatus, from ...EnvironmentStatus) (Environment, error) {
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return Environment{}, err
+	}
+	if isTransitional(env.Status) {
+		return Environment{}, fmt.Errorf("environment is %s, wait for it to finish", env.Status)
+	}
+	if !slices.Contains(from, env.Status) {
+		return Environment{}, fmt.Errorf("environment can't go from %s to %s", env.Status, to)
+	}
+
+	before := env.clone()
+	env.Status = to
+	return before, nil
+}
+
+// finishTransition sets the status an environment and its modules end up in after a
+// lifecycle operation, and records environments that were started or stopped
+func (s *SolutionService) finishTransition(solutionId string, environmentId string, status EnvironmentStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	solution, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return
+	}
+	env.Status = status
+	if status != EnvironmentStatusError {
+		for i := range env.Modules {
+			env.Modules[i].Status = status
+		}
+	}
+	if status == EnvironmentStatusRunning {
+		env.LastDeployed = time.Now()
+	}
+	solution.UpdatedAt = time.Now()
+
+	switch status {
+	case EnvironmentStatusRunning:
+		s.recordDeployment(solutionId, *env, DeploymentEventStart, "Environment started", nil)
+	case EnvironmentStatusStopped:
+		s.recordDeployment(solutionId, *env, DeploymentEventStop, "Environment stopped", nil)
+	}
+}
+
+// UpdateEnvironment renames an environment or moves it to another namespace. The ID stays
+// the same. The namespace can only be changed while the environment is stopped, otherwise
+// whatever runs in the old namespace would be left behind.
+func (s *SolutionService) UpdateEnvironment(solutionId string, environmentId string, req UpdateEnvironmentRequest) (Environment, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Namespace = strings.TrimSpace(req.Namespace)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	solution, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return Environment{}, err
+	}
+	if isTransitional(env.Status) {
+		return Environment{}, fmt.Errorf("environment is %s, wait for it to finish", env.Status)
+	}
+	if errors := s.validateEnvironmentRequest(solution, req.Name, req.Namespace, env.ID); len(errors) > 0 {
+		return Environment{}, fmt.Errorf("%s", errors[0].Message)
+	}
+	if req.Namespace != env.Namespace && env.Status != EnvironmentStatusStopped {
+		return Environment{}, fmt.Errorf("stop the environment before moving it to another namespace")
+	}
+
+	env.Name = req.Name
+	env.Namespace = req.Namespace
+	solution.UpdatedAt = time.Now()
+	return env.clone(), nil
+}
+
+// DeleteEnvironment tears down everything deployed for an environment, including its
+// namespace, and removes it along with its configuration and history. It runs in the
+// background, the returned operation ID tracks it.
+func (s *SolutionService) DeleteEnvironment(solutionId string, environmentId string) (string, error) {
+	env, err := s.beginTransition(solutionId, environmentId, EnvironmentStatusDeleting,
+		EnvironmentStatusRunning, EnvironmentStatusStopped, EnvironmentStatusError)
+	if err != nil {
+		return "", err
+	}
+
+	op := s.operations.start(Operation{
+		Kind:          "delete",
+		Title:         fmt.Sprintf("Delete %s", env.Name),
+		SolutionID:    solutionId,
+		EnvironmentID: environmentId,
+	}, []string{"Tear down namespace", "Remove environment"}, func(ctx context.Context, run *operationRun) error {
+		run.step("Tear down namespace")
+		ctx, cancel := context.WithTimeout(ctx, deployTimeout)
+		defer cancel()
+		if err := s.deployer.Teardown(ctx, solutionId, env); err != nil {
+			s.finishTransition(solutionId, environmentId, EnvironmentStatusError)
+			return fmt.Errorf("failed to tear down namespace %s: %w", env.Namespace, err)
+		}
+
+		run.step("Remove environment")
+		if err := s.store.Remove(environmentDir(solutionId, environmentId)...); err != nil {
+			s.finishTransition(solutionId, environmentId, EnvironmentStatusError)
+			return fmt.Errorf("failed to remove environment data: %w", err)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		solution, deleted, err := s.findEnvironment(solutionId, environmentId)
+		if err != nil {
+			return nil
+		}
+		s.recordDeployment(solutionId, *deleted, DeploymentEventDelete, fmt.Sprintf("Environment deleted along with namespace %s", deleted.Namespace), nil)
+		solution.Environments = slices.DeleteFunc(solution.Environments, func(e Environment) bool {
+			return e.ID == environmentId
+		})
+		solution.UpdatedAt = time.Now()
+		return nil
+	})
+	return op.ID, nil
+}
+
+// StopEnvironment scales all components of an environment to zero. The configuration and
+// data stay, StartEnvironment brings it back. It runs in the background, the returned
+// operation ID tracks it.
+func (s *SolutionService) StopEnvironment(solutionId string, environmentId string) (string, error) {
+	return s.scaleEnvironment(solutionId, environmentId, func(plan []ComponentResourcePlan) ([]ComponentResourcePlan, error) {
+		for i := range plan {
+			plan[i].Replicas = 0
+		}
+		return plan, nil
+	}, "stop", EnvironmentStatusStopping, EnvironmentStatusStopped, EnvironmentStatusRunning, EnvironmentStatusError)
+}
+
+// StartEnvironment scales the components of a stopped environment back to the replicas
+// its resource profile and overrides give them. It runs in the background, the returned
+// operation ID tracks it.
+func (s *SolutionService) StartEnvironment(solutionId string, environmentId string) (string, error) {
+	return s.scaleEnvironment(solutionId, environmentId, nil, "start", EnvironmentStatusStarting, EnvironmentStatusRunning,
+		EnvironmentStatusStopped, EnvironmentStatusError)
+}
+
+// scaleEnvironment moves an environment through a transitional status while an operation
+// scales the components pick chooses, see beginPlannedTransition. It ends up in status
+// done, or in error if scaling fails or is cancelled since some components may have been
+// scaled already.
+func (s *SolutionService) scaleEnvironment(solutionId string, environmentId string, pick func(plan []ComponentResourcePlan) ([]ComponentResourcePlan, error), kind string, transition EnvironmentStatus, done EnvironmentStatus, from ...EnvironmentStatus) (string, error) {
+	env, plan, err := s.beginPlannedTransition(solutionId, environmentId, transition, pick, from...)
+	if err != nil {
+		return "", err
+	}
+
+	steps := []string{}
+	for _, component := range plan {
+		steps = append(steps, fmt.Sprintf("Scale %s/%s to %d", component.ModuleID, component.ComponentID, component.Replicas))
+	}
+	op := s.operations.start(Operation{
+		Kind:          kind,
+		Title:         fmt.Sprintf("%s %s", strings.ToUpper(kind[:1])+kind[1:], env.Name),
+		SolutionID:    solutionId,
+		EnvironmentID: environmentId,
+	}, steps, func(ctx context.Context, run *operationRun) error {
+		ctx, cancel := context.WithTimeout(ctx, deployTimeout)
+		defer cancel()
+		for i, component := range plan {
+			run.step(steps[i])
+			if err := s.deployer.Scale(ctx, solutionId, env, []ComponentResourcePlan{component}); err != nil {
+				s.finishTransition(solutionId, environmentId, EnvironmentStatusError)
+				return fmt.Errorf("failed to %s environment: %w", kind, err)
+			}
+		}
+		s.finishTransition(solutionId, environmentId, done)
+		return nil
+	})
+	return op.ID, nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// observeTimeout bounds asking the deployer what runs or for logs
+const observeTimeout = 30 * time.Second
+
+// maxLogLines is how many of the latest log lines of a component are read
+const maxLogLines = 500
+
+// componentPlan returns the planned components of env as given, which may not be stored
+// yet, for the deployer
+func (s *SolutionService) componentPlan(solutionId string, env Environment) ([]ComponentResourcePlan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.plannedComponents(solutionId, env)
+}
+
+// plannedComponents is componentPlan for callers that already hold s.mu
+func (s *SolutionService) plannedComponents(solutionId string, env Environment) ([]ComponentResourcePlan, error) {
+	solutionDoc, err := s.loadSolutionDocument(solutionId)
+	if err != nil {
+		return nil, err
+	}
+	envDoc, err := s.loadEnvironmentDocument(solutionId, env)
+	if err != nil {
+		return nil, err
+	}
+	return s.effectiveConfig(solutionId, env, solutionDoc, envDoc).Resources.Components, nil
+}
+
+// RefreshEnvironmentStatus updates the status of an environment and its modules from what
+// actually runs and returns the environment. A module is running when all its components
+// run, stopped when none does and in error if some don't or any crashed. Environments
+// with a change in progress, and deployers that can't tell what runs, leave it as it is.
+func (s *SolutionService) RefreshEnvironmentStatus(solutionId string, environmentId string) (Environment, error) {
+	env, err := s.environment(solutionId, environmentId)
+	if err != nil {
+		return Environment{}, err
+	}
+	observer, ok := s.deployer.(componentObserver)
+	if !ok || isTransitional(env.Status) {
+		return env, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), observeTimeout)
+	defer cancel()
+	states, err := observer.Status(ctx, solutionId, env)
+	if err != nil {
+		return env, fmt.Errorf("failed to read the status of %s: %w", env.Name, err)
+	}
+	observed := make(map[string]componentState, len(states))
+	for _, state := range states {
+		observed[state.ModuleID+"/"+state.ComponentID] = state
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, current, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return Environment{}, err
+	}
+	// A change may have started while the deployer was asked
+	if isTransitional(current.Status) {
+		return current.clone(), nil
+	}
+	// Modules may have been installed since, plan what the environment is now
+	plan, err := s.plannedComponents(solutionId, *current)
+	if err != nil {
+		return current.clone(), err
+	}
+
+	statuses := make(map[EnvironmentStatus]int)
+	for i := range current.Modules {
+		module := &current.Modules[i]
+		if isTransitional(module.Status) {
+			statuses[module.Status]++
+			continue
+		}
+		expected, running, failed := 0, 0, false
+		for _, component := range plan {
+			if component.ModuleID != module.ModuleID {
+				continue
+			}
+			state := observed[component.ModuleID+"/"+component.ComponentID]
+			failed = failed || state.Failed
+			// Setup components finish once they've done their work
+			if component.Type == ComponentTypeSetup {
+				continue
+			}
+			expected++
+			if state.Running > 0 {
+				running++
+			}
+		}
+		switch {
+		case failed, running > 0 && running < expected:
+			module.Status = EnvironmentStatusError
+		case running == 0:
+			module.Status = EnvironmentStatusStopped
+		default:
+			module.Status = EnvironmentStatusRunning
+		}
+		statuses[module.Status]++
+	}
+
+	switch {
+	case len(current.Modules) == 0:
+	case statuses[EnvironmentStatusError] > 0:
+		current.Status = EnvironmentStatusError
+	case statuses[EnvironmentStatusRunning] > 0:
+		current.Status = EnvironmentStatusRunning
+	default:
+		current.Status = EnvironmentStatusStopped
+	}
+	return current.clone(), nil
+}
+
+// environment returns a copy of an environment
+func (s *SolutionService) environment(solutionId string, environmentId string) (Environment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return Environment{}, err
+	}
+	return env.clone(), nil
+}
+
+// componentLogs reads the latest logs of a component from where it runs. ok is false if
+// the deployer can't read logs. componentId may be qualified with its module like for
+// SyncComponents.
+func (s *SolutionService) componentLogs(solutionId string, environmentId string, componentId string) (logs []LogEntry, ok bool, err error) {
+	observer, ok := s.deployer.(componentObserver)
+	if !ok {
+		return nil, false, nil
+	}
+	env, err := s.environment(solutionId, environmentId)
+	if err != nil {
+		return nil, true, err
+	}
+	plan, err := s.componentPlan(solutionId, env)
+	if err != nil {
+		return nil, true, err
+	}
+	components, err := selectComponents(plan, []string{componentId})
+	if err != nil {
+		return nil, true, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), observeTimeout)
+	defer cancel()
+	logs, err = observer.Logs(ctx, solutionId, env, components[0], maxLogLines)
+	if err != nil {
+		return nil, true, err
+	}
+	for i := range logs {
+		logs[i].Message = secretMasker.Mask(logs[i].Message)
+	}
+	return logs, true, nil
+}
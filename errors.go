@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/go-github/v69/github"
+)
+
+// ErrorKind classifies lookup failures so the frontend can show an actionable message
+type ErrorKind string
+
+const (
+	ErrorKindNotFound     ErrorKind = "not_found"
+	ErrorKindUnauthorized ErrorKind = "unauthorized"
+	ErrorKindRateLimited  ErrorKind = "rate_limited"
+	ErrorKindNetwork      ErrorKind = "network"
+	ErrorKindInvalid      ErrorKind = "invalid"
+	ErrorKindUnknown      ErrorKind = "unknown"
+)
+
+// LookupError is returned when fetching data for a module fails. Wails only passes the
+// error message to the frontend, so bindings that need the kind return it as part of
+// their result instead.
+type LookupError struct {
+	Kind    ErrorKind  `json:"kind"`
+	Message string     `json:"message"`
+	ResetAt *time.Time `json:"resetAt,omitempty" ts_type:"string"`
+	Err     error      `json:"-"`
+}
+
+// Sentinel errors for use with errors.Is. Only the kind is compared.
+var (
+	ErrNotFound     = &LookupError{Kind: ErrorKindNotFound}
+	ErrUnauthorized = &LookupError{Kind: ErrorKindUnauthorized}
+	ErrRateLimited  = &LookupError{Kind: ErrorKindRateLimited}
+	ErrNetwork      = &LookupError{Kind: ErrorKindNetwork}
+	ErrInvalid      = &LookupError{Kind: ErrorKindInvalid}
+)
+
+func (e *LookupError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return string(e.Kind)
+}
+
+func (e *LookupError) Unwrap() error {
+	return e.Err
+}
+
+func (e *LookupError) Is(target error) bool {
+	t, ok := target.(*LookupError)
+	return ok && t.Kind == e.Kind
+}
+
+func newLookupError(kind ErrorKind, err error, format string, args ...any) *LookupError {
+	return &LookupError{
+		Kind:    kind,
+		Message: fmt.Sprintf(format, args...),
+		Err:     err,
+	}
+}
+
+// asLookupError returns err as a *LookupError, classifying it as unknown if it isn't one
+func asLookupError(err error) *LookupError {
+	if err == nil {
+		return nil
+	}
+	var lookupErr *LookupError
+	if errors.As(err, &lookupErr) {
+		return lookupErr
+	}
+	return newLookupError(ErrorKindUnknown, err, "%s", err.Error())
+}
+
+// classifyGitHubError maps errors returned by the GitHub client onto the LookupError taxonomy
+func classifyGitHubError(err error, resource string) *LookupError {
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		reset := rateErr.Rate.Reset.Time
+		lookupErr := newLookupError(ErrorKindRateLimited, err, "GitHub rate limit exceeded while fetching %s, resets at %s", resource, reset.Local().Format(time.Kitchen))
+		lookupErr.ResetAt = &reset
+		return lookupErr
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		lookupErr := newLookupError(ErrorKindRateLimited, err, "GitHub secondary rate limit hit while fetching %s", resource)
+		if retryAfter := abuseErr.GetRetryAfter(); retryAfter > 0 {
+			reset := time.Now().Add(retryAfter)
+			lookupErr.ResetAt = &reset
+		}
+		return lookupErr
+	}
+
+	var respErr *github.ErrorResponse
+	if errors.As(err, &respErr) && respErr.Response != nil {
+		switch respErr.Response.StatusCode {
+		case http.StatusNotFound:
+			return newLookupError(ErrorKindNotFound, err, "%s not found on GitHub", resource)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return newLookupError(ErrorKindUnauthorized, err, "not authorized to access %s on GitHub", resource)
+		}
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return newLookupError(ErrorKindNetwork, err, "could not reach GitHub while fetching %s", resource)
+	}
+
+	return newLookupError(ErrorKindUnknown, err, "failed to fetch %s: %s", resource, err.Error())
+}
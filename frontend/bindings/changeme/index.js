@@ -4,11 +4,13 @@
 
 import * as LogService from "./logservice.js";
 import * as ModuleService from "./moduleservice.js";
+import * as OperationService from "./operationservice.js";
 import * as SolutionService from "./solutionservice.js";
 import * as SystemService from "./systemservice.js";
 export {
     LogService,
     ModuleService,
+    OperationService,
     SolutionService,
     SystemService
 };
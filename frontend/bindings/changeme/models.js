@@ -6,6 +6,12 @@
 // @ts-ignore: Unused imports
 import {Create as $Create} from "@wailsio/runtime";
 
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as json$0 from "../encoding/json/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as jsontext$0 from "../encoding/json/jsontext/models.js";
 // eslint-disable-next-line @typescript-eslint/ban-ts-comment
 // @ts-ignore: Unused imports
 import * as time$0 from "../time/models.js";
@@ -46,66 +52,142 @@ export class AddEnvironmentRequest {
 }
 
 /**
- * @readonly
- * @enum {string}
+ * AddEnvironmentResult tells the frontend whether an environment was added and if not, why
  */
-export const ComponentType = {
+export class AddEnvironmentResult {
     /**
-     * The Go zero value for the underlying type of the enum.
+     * Creates a new AddEnvironmentResult instance.
+     * @param {Partial<AddEnvironmentResult>} [$$source = {}] - The source object to create the AddEnvironmentResult.
      */
-    $zero: "",
+    constructor($$source = {}) {
+        if (!("added" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["added"] = false;
+        }
+        if (!("environment" in $$source)) {
+            /**
+             * @member
+             * @type {Environment}
+             */
+            this["environment"] = (new Environment());
+        }
+        if (!("errors" in $$source)) {
+            /**
+             * @member
+             * @type {FieldError[]}
+             */
+            this["errors"] = [];
+        }
 
-    ComponentTypeBackend: "Backend",
-    ComponentTypeFrontend: "Frontend",
-    ComponentTypeApiGateway: "ApiGateway",
-    ComponentTypeSetup: "Setup",
-};
+        Object.assign(this, $$source);
+    }
 
-export class Environment {
     /**
-     * Creates a new Environment instance.
-     * @param {Partial<Environment>} [$$source = {}] - The source object to create the Environment.
+     * Creates a new AddEnvironmentResult instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {AddEnvironmentResult}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType0;
+        const $$createField2_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("environment" in $$parsedSource) {
+            $$parsedSource["environment"] = $$createField1_0($$parsedSource["environment"]);
+        }
+        if ("errors" in $$parsedSource) {
+            $$parsedSource["errors"] = $$createField2_0($$parsedSource["errors"]);
+        }
+        return new AddEnvironmentResult(/** @type {Partial<AddEnvironmentResult>} */($$parsedSource));
+    }
+}
+
+/**
+ * CatalogDiagnostic is a single problem found in a module's catalog entry. Field is a
+ * JSON path into the module, e.g. "dependencies[0].id".
+ */
+export class CatalogDiagnostic {
+    /**
+     * Creates a new CatalogDiagnostic instance.
+     * @param {Partial<CatalogDiagnostic>} [$$source = {}] - The source object to create the CatalogDiagnostic.
      */
     constructor($$source = {}) {
-        if (!("id" in $$source)) {
+        if (!("severity" in $$source)) {
+            /**
+             * @member
+             * @type {DiagnosticSeverity}
+             */
+            this["severity"] = (/** @type {DiagnosticSeverity} */(""));
+        }
+        if (!("code" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["id"] = "";
+            this["code"] = "";
         }
-        if (!("name" in $$source)) {
+        if (!("field" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["name"] = "";
+            this["field"] = "";
         }
-        if (!("namespace" in $$source)) {
+        if (!("message" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["namespace"] = "";
+            this["message"] = "";
         }
-        if (!("status" in $$source)) {
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CatalogDiagnostic instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {CatalogDiagnostic}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new CatalogDiagnostic(/** @type {Partial<CatalogDiagnostic>} */($$parsedSource));
+    }
+}
+
+export class CatalogValidation {
+    /**
+     * Creates a new CatalogValidation instance.
+     * @param {Partial<CatalogValidation>} [$$source = {}] - The source object to create the CatalogValidation.
+     */
+    constructor($$source = {}) {
+        if (!("valid" in $$source)) {
             /**
              * @member
-             * @type {EnvironmentStatus}
+             * @type {boolean}
              */
-            this["status"] = (/** @type {EnvironmentStatus} */(""));
+            this["valid"] = false;
         }
-        if (!("lastDeployed" in $$source)) {
+        if (!("errors" in $$source)) {
             /**
              * @member
-             * @type {time$0.Time}
+             * @type {number}
              */
-            this["lastDeployed"] = null;
+            this["errors"] = 0;
+        }
+        if (!("warnings" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["warnings"] = 0;
         }
         if (!("modules" in $$source)) {
             /**
              * @member
-             * @type {EnvironmentModule[]}
+             * @type {ModuleDiagnostics[]}
              */
             this["modules"] = [];
         }
@@ -114,24 +196,28 @@ export class Environment {
     }
 
     /**
-     * Creates a new Environment instance from a string or object.
+     * Creates a new CatalogValidation instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {Environment}
+     * @returns {CatalogValidation}
      */
     static createFrom($$source = {}) {
-        const $$createField5_0 = $$createType1;
+        const $$createField3_0 = $$createType4;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
         if ("modules" in $$parsedSource) {
-            $$parsedSource["modules"] = $$createField5_0($$parsedSource["modules"]);
+            $$parsedSource["modules"] = $$createField3_0($$parsedSource["modules"]);
         }
-        return new Environment(/** @type {Partial<Environment>} */($$parsedSource));
+        return new CatalogValidation(/** @type {Partial<CatalogValidation>} */($$parsedSource));
     }
 }
 
-export class EnvironmentModule {
+/**
+ * ComponentResourcePlan is what a component is deployed with after applying the resource
+ * profile and overrides
+ */
+export class ComponentResourcePlan {
     /**
-     * Creates a new EnvironmentModule instance.
-     * @param {Partial<EnvironmentModule>} [$$source = {}] - The source object to create the EnvironmentModule.
+     * Creates a new ComponentResourcePlan instance.
+     * @param {Partial<ComponentResourcePlan>} [$$source = {}] - The source object to create the ComponentResourcePlan.
      */
     constructor($$source = {}) {
         if (!("moduleId" in $$source)) {
@@ -141,357 +227,3468 @@ export class EnvironmentModule {
              */
             this["moduleId"] = "";
         }
-        if (!("version" in $$source)) {
+        if (!("componentId" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["version"] = "";
+            this["componentId"] = "";
         }
-        if (!("status" in $$source)) {
+        if (!("componentName" in $$source)) {
             /**
              * @member
-             * @type {EnvironmentStatus}
+             * @type {string}
              */
-            this["status"] = (/** @type {EnvironmentStatus} */(""));
+            this["componentName"] = "";
+        }
+        if (!("type" in $$source)) {
+            /**
+             * @member
+             * @type {ComponentType}
+             */
+            this["type"] = (/** @type {ComponentType} */(""));
+        }
+        if (!("replicas" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["replicas"] = 0;
+        }
+        if (!("requests" in $$source)) {
+            /**
+             * @member
+             * @type {ResourceQuantities}
+             */
+            this["requests"] = (new ResourceQuantities());
+        }
+        if (!("limits" in $$source)) {
+            /**
+             * @member
+             * @type {ResourceQuantities}
+             */
+            this["limits"] = (new ResourceQuantities());
+        }
+        if (!("overridden" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["overridden"] = false;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new EnvironmentModule instance from a string or object.
+     * Creates a new ComponentResourcePlan instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {EnvironmentModule}
+     * @returns {ComponentResourcePlan}
      */
     static createFrom($$source = {}) {
+        const $$createField5_0 = $$createType5;
+        const $$createField6_0 = $$createType5;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new EnvironmentModule(/** @type {Partial<EnvironmentModule>} */($$parsedSource));
+        if ("requests" in $$parsedSource) {
+            $$parsedSource["requests"] = $$createField5_0($$parsedSource["requests"]);
+        }
+        if ("limits" in $$parsedSource) {
+            $$parsedSource["limits"] = $$createField6_0($$parsedSource["limits"]);
+        }
+        return new ComponentResourcePlan(/** @type {Partial<ComponentResourcePlan>} */($$parsedSource));
     }
 }
 
 /**
- * @readonly
- * @enum {string}
+ * ComponentResources are the resources a component is deployed with
  */
-export const EnvironmentStatus = {
+export class ComponentResources {
     /**
-     * The Go zero value for the underlying type of the enum.
-     */
-    $zero: "",
-
-    EnvironmentStatusRunning: "running",
-    EnvironmentStatusStopped: "stopped",
-    EnvironmentStatusError: "error",
-};
-
-export class LogEntry {
-    /**
-     * Creates a new LogEntry instance.
-     * @param {Partial<LogEntry>} [$$source = {}] - The source object to create the LogEntry.
+     * Creates a new ComponentResources instance.
+     * @param {Partial<ComponentResources>} [$$source = {}] - The source object to create the ComponentResources.
      */
     constructor($$source = {}) {
-        if (!("timestamp" in $$source)) {
+        if (!("replicas" in $$source)) {
             /**
              * @member
-             * @type {time$0.Time}
+             * @type {number}
              */
-            this["timestamp"] = null;
+            this["replicas"] = 0;
         }
-        if (!("level" in $$source)) {
+        if (!("requests" in $$source)) {
             /**
              * @member
-             * @type {string}
+             * @type {ResourceQuantities}
              */
-            this["level"] = "";
+            this["requests"] = (new ResourceQuantities());
         }
-        if (!("message" in $$source)) {
+        if (!("limits" in $$source)) {
             /**
              * @member
-             * @type {string}
+             * @type {ResourceQuantities}
              */
-            this["message"] = "";
+            this["limits"] = (new ResourceQuantities());
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new LogEntry instance from a string or object.
+     * Creates a new ComponentResources instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {LogEntry}
+     * @returns {ComponentResources}
      */
     static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType5;
+        const $$createField2_0 = $$createType5;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new LogEntry(/** @type {Partial<LogEntry>} */($$parsedSource));
+        if ("requests" in $$parsedSource) {
+            $$parsedSource["requests"] = $$createField1_0($$parsedSource["requests"]);
+        }
+        if ("limits" in $$parsedSource) {
+            $$parsedSource["limits"] = $$createField2_0($$parsedSource["limits"]);
+        }
+        return new ComponentResources(/** @type {Partial<ComponentResources>} */($$parsedSource));
     }
 }
 
-export class ModuleAttributes {
+/**
+ * @readonly
+ * @enum {string}
+ */
+export const ComponentType = {
     /**
-     * Creates a new ModuleAttributes instance.
-     * @param {Partial<ModuleAttributes>} [$$source = {}] - The source object to create the ModuleAttributes.
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    ComponentTypeBackend: "Backend",
+    ComponentTypeFrontend: "Frontend",
+    ComponentTypeApiGateway: "ApiGateway",
+    ComponentTypeSetup: "Setup",
+};
+
+/**
+ * ConfigChange is a single value that differs between two configurations. Path is a
+ * dotted path into the document, e.g. "spec.modules.flow.values.replicas". Secret is set
+ * when either side is a secret reference, Before and After then hold the references.
+ */
+export class ConfigChange {
+    /**
+     * Creates a new ConfigChange instance.
+     * @param {Partial<ConfigChange>} [$$source = {}] - The source object to create the ConfigChange.
      */
     constructor($$source = {}) {
-        if (/** @type {any} */(false)) {
+        if (!("path" in $$source)) {
             /**
              * @member
-             * @type {string | undefined}
+             * @type {string}
              */
-            this["githubRepo"] = "";
+            this["path"] = "";
         }
-        if (/** @type {any} */(false)) {
+        if (!("kind" in $$source)) {
             /**
              * @member
-             * @type {string | undefined}
+             * @type {ConfigChangeKind}
              */
-            this["documentation"] = "";
+            this["kind"] = (/** @type {ConfigChangeKind} */(""));
         }
-        if (/** @type {any} */(false)) {
+        if (!("before" in $$source)) {
             /**
              * @member
-             * @type {string | undefined}
+             * @type {any}
              */
-            this["website"] = "";
+            this["before"] = null;
         }
-        if (/** @type {any} */(false)) {
+        if (!("after" in $$source)) {
             /**
              * @member
-             * @type {string | undefined}
+             * @type {any}
              */
-            this["license"] = "";
+            this["after"] = null;
         }
-        if (/** @type {any} */(false)) {
+        if (!("secret" in $$source)) {
             /**
              * @member
-             * @type {{ [_: string]: string } | undefined}
+             * @type {boolean}
              */
-            this["packages"] = {};
+            this["secret"] = false;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new ModuleAttributes instance from a string or object.
+     * Creates a new ConfigChange instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {ModuleAttributes}
+     * @returns {ConfigChange}
      */
     static createFrom($$source = {}) {
-        const $$createField4_0 = $$createType2;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        if ("packages" in $$parsedSource) {
-            $$parsedSource["packages"] = $$createField4_0($$parsedSource["packages"]);
-        }
-        return new ModuleAttributes(/** @type {Partial<ModuleAttributes>} */($$parsedSource));
+        return new ConfigChange(/** @type {Partial<ConfigChange>} */($$parsedSource));
     }
 }
 
-export class ModuleComponent {
+/**
+ * @readonly
+ * @enum {string}
+ */
+export const ConfigChangeKind = {
     /**
-     * Creates a new ModuleComponent instance.
-     * @param {Partial<ModuleComponent>} [$$source = {}] - The source object to create the ModuleComponent.
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    ConfigChangeAdded: "added",
+    ConfigChangeRemoved: "removed",
+    ConfigChangeChanged: "changed",
+};
+
+/**
+ * ConfigComparison is an effective configuration value that isn't the same everywhere.
+ * Values holds the value per environment ID, environments without the value are left out.
+ * Baseline is what the solution's configuration and the module defaults give.
+ */
+export class ConfigComparison {
+    /**
+     * Creates a new ConfigComparison instance.
+     * @param {Partial<ConfigComparison>} [$$source = {}] - The source object to create the ConfigComparison.
      */
     constructor($$source = {}) {
-        if (!("id" in $$source)) {
+        if (!("path" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["id"] = "";
+            this["path"] = "";
         }
-        if (!("name" in $$source)) {
+        if (!("baseline" in $$source)) {
             /**
              * @member
-             * @type {string}
+             * @type {any}
              */
-            this["name"] = "";
+            this["baseline"] = null;
         }
-        if (!("type" in $$source)) {
+        if (!("values" in $$source)) {
             /**
              * @member
-             * @type {ComponentType}
+             * @type {{ [_: string]: any }}
              */
-            this["type"] = (/** @type {ComponentType} */(""));
+            this["values"] = {};
         }
-        if (!("description" in $$source)) {
+        if (!("secret" in $$source)) {
             /**
              * @member
-             * @type {string}
+             * @type {boolean}
              */
-            this["description"] = "";
+            this["secret"] = false;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new ModuleComponent instance from a string or object.
+     * Creates a new ConfigComparison instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {ModuleComponent}
+     * @returns {ConfigComparison}
      */
     static createFrom($$source = {}) {
+        const $$createField2_0 = $$createType6;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new ModuleComponent(/** @type {Partial<ModuleComponent>} */($$parsedSource));
+        if ("values" in $$parsedSource) {
+            $$parsedSource["values"] = $$createField2_0($$parsedSource["values"]);
+        }
+        return new ConfigComparison(/** @type {Partial<ConfigComparison>} */($$parsedSource));
     }
 }
 
-export class ModuleDependency {
+export class ConfigDiff {
     /**
-     * Creates a new ModuleDependency instance.
-     * @param {Partial<ModuleDependency>} [$$source = {}] - The source object to create the ModuleDependency.
+     * Creates a new ConfigDiff instance.
+     * @param {Partial<ConfigDiff>} [$$source = {}] - The source object to create the ConfigDiff.
      */
     constructor($$source = {}) {
-        if (!("id" in $$source)) {
+        if (!("from" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["id"] = "";
+            this["from"] = "";
         }
-        if (!("name" in $$source)) {
+        if (!("to" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["name"] = "";
+            this["to"] = "";
         }
-        if (!("version" in $$source)) {
+        if (!("changes" in $$source)) {
             /**
              * @member
-             * @type {string}
+             * @type {ConfigChange[]}
              */
-            this["version"] = "";
+            this["changes"] = [];
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new ModuleDependency instance from a string or object.
+     * Creates a new ConfigDiff instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {ModuleDependency}
+     * @returns {ConfigDiff}
      */
     static createFrom($$source = {}) {
+        const $$createField2_0 = $$createType8;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new ModuleDependency(/** @type {Partial<ModuleDependency>} */($$parsedSource));
+        if ("changes" in $$parsedSource) {
+            $$parsedSource["changes"] = $$createField2_0($$parsedSource["changes"]);
+        }
+        return new ConfigDiff(/** @type {Partial<ConfigDiff>} */($$parsedSource));
     }
 }
 
 /**
- * ModuleResponse is used for API responses to ensure consistent JSON serialization
+ * ConfigIssue is a problem found in an environment configuration. Path is the dotted
+ * path of the offending key, Line is 0 when the position is unknown.
  */
-export class ModuleResponse {
+export class ConfigIssue {
     /**
-     * Creates a new ModuleResponse instance.
-     * @param {Partial<ModuleResponse>} [$$source = {}] - The source object to create the ModuleResponse.
+     * Creates a new ConfigIssue instance.
+     * @param {Partial<ConfigIssue>} [$$source = {}] - The source object to create the ConfigIssue.
      */
     constructor($$source = {}) {
-        if (!("id" in $$source)) {
+        if (!("path" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["id"] = "";
+            this["path"] = "";
         }
-        if (!("name" in $$source)) {
+        if (!("line" in $$source)) {
             /**
              * @member
-             * @type {string}
+             * @type {number}
              */
-            this["name"] = "";
+            this["line"] = 0;
         }
-        if (!("description" in $$source)) {
+        if (!("message" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["description"] = "";
+            this["message"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ConfigIssue instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ConfigIssue}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ConfigIssue(/** @type {Partial<ConfigIssue>} */($$parsedSource));
+    }
+}
+
+/**
+ * ConfigLayer is a level of configuration. Later layers override earlier ones:
+ * module defaults, then solution values, then environment overrides.
+ * @readonly
+ * @enum {string}
+ */
+export const ConfigLayer = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    ConfigLayerDefault: "default",
+    ConfigLayerSolution: "solution",
+    ConfigLayerEnvironment: "environment",
+};
+
+/**
+ * ConfigRevision is a saved version of an environment's configuration. YAML is only
+ * filled in when a single revision is requested.
+ */
+export class ConfigRevision {
+    /**
+     * Creates a new ConfigRevision instance.
+     * @param {Partial<ConfigRevision>} [$$source = {}] - The source object to create the ConfigRevision.
+     */
+    constructor($$source = {}) {
+        if (!("revision" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["revision"] = 0;
+        }
+        if (!("author" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["author"] = "";
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+        if (!("createdAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["createdAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {number | undefined}
+             */
+            this["rollbackFrom"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["yaml"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ConfigRevision instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ConfigRevision}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ConfigRevision(/** @type {Partial<ConfigRevision>} */($$parsedSource));
+    }
+}
+
+/**
+ * ConfigSaveResult tells the frontend whether a configuration was saved and if not, why.
+ * OperationID tracks deploying a saved configuration, if it's deployed.
+ */
+export class ConfigSaveResult {
+    /**
+     * Creates a new ConfigSaveResult instance.
+     * @param {Partial<ConfigSaveResult>} [$$source = {}] - The source object to create the ConfigSaveResult.
+     */
+    constructor($$source = {}) {
+        if (!("saved" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["saved"] = false;
+        }
+        if (!("config" in $$source)) {
+            /**
+             * @member
+             * @type {EnvironmentConfig}
+             */
+            this["config"] = (new EnvironmentConfig());
+        }
+        if (!("issues" in $$source)) {
+            /**
+             * @member
+             * @type {ConfigIssue[]}
+             */
+            this["issues"] = [];
+        }
+        if (!("operationId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["operationId"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ConfigSaveResult instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ConfigSaveResult}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType9;
+        const $$createField2_0 = $$createType11;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("config" in $$parsedSource) {
+            $$parsedSource["config"] = $$createField1_0($$parsedSource["config"]);
+        }
+        if ("issues" in $$parsedSource) {
+            $$parsedSource["issues"] = $$createField2_0($$parsedSource["issues"]);
+        }
+        return new ConfigSaveResult(/** @type {Partial<ConfigSaveResult>} */($$parsedSource));
+    }
+}
+
+/**
+ * DependencyEdge points from a module to one of its dependencies
+ */
+export class DependencyEdge {
+    /**
+     * Creates a new DependencyEdge instance.
+     * @param {Partial<DependencyEdge>} [$$source = {}] - The source object to create the DependencyEdge.
+     */
+    constructor($$source = {}) {
+        if (!("from" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["from"] = "";
+        }
+        if (!("to" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["to"] = "";
+        }
+        if (!("requiredVersion" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["requiredVersion"] = "";
+        }
+        if (!("inCycle" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["inCycle"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new DependencyEdge instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {DependencyEdge}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new DependencyEdge(/** @type {Partial<DependencyEdge>} */($$parsedSource));
+    }
+}
+
+export class DependencyGraph {
+    /**
+     * Creates a new DependencyGraph instance.
+     * @param {Partial<DependencyGraph>} [$$source = {}] - The source object to create the DependencyGraph.
+     */
+    constructor($$source = {}) {
+        if (!("nodes" in $$source)) {
+            /**
+             * @member
+             * @type {DependencyNode[]}
+             */
+            this["nodes"] = [];
+        }
+        if (!("edges" in $$source)) {
+            /**
+             * @member
+             * @type {DependencyEdge[]}
+             */
+            this["edges"] = [];
+        }
+        if (!("cycles" in $$source)) {
+            /**
+             * @member
+             * @type {string[][]}
+             */
+            this["cycles"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new DependencyGraph instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {DependencyGraph}
+     */
+    static createFrom($$source = {}) {
+        const $$createField0_0 = $$createType13;
+        const $$createField1_0 = $$createType15;
+        const $$createField2_0 = $$createType17;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("nodes" in $$parsedSource) {
+            $$parsedSource["nodes"] = $$createField0_0($$parsedSource["nodes"]);
+        }
+        if ("edges" in $$parsedSource) {
+            $$parsedSource["edges"] = $$createField1_0($$parsedSource["edges"]);
+        }
+        if ("cycles" in $$parsedSource) {
+            $$parsedSource["cycles"] = $$createField2_0($$parsedSource["cycles"]);
+        }
+        return new DependencyGraph(/** @type {Partial<DependencyGraph>} */($$parsedSource));
+    }
+}
+
+/**
+ * DependencyNode is a module in a dependency graph. Missing is set for dependencies
+ * that refer to a module that isn't in the catalog.
+ */
+export class DependencyNode {
+    /**
+     * Creates a new DependencyNode instance.
+     * @param {Partial<DependencyNode>} [$$source = {}] - The source object to create the DependencyNode.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("version" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["version"] = "";
+        }
+        if (!("depth" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["depth"] = 0;
+        }
+        if (!("missing" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["missing"] = false;
+        }
+        if (!("inCycle" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["inCycle"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new DependencyNode instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {DependencyNode}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new DependencyNode(/** @type {Partial<DependencyNode>} */($$parsedSource));
+    }
+}
+
+/**
+ * DeploymentEvent is an entry in the deployment history of a solution. Modules and
+ * ConfigRevision capture the environment as it was right after the event, so it can be
+ * restored later. Events without an EnvironmentID apply to the solution as a whole, such
+ * as changes to the solution's configuration. Changes lists the modules the event
+ * installed, upgraded, downgraded or uninstalled.
+ */
+export class DeploymentEvent {
+    /**
+     * Creates a new DeploymentEvent instance.
+     * @param {Partial<DeploymentEvent>} [$$source = {}] - The source object to create the DeploymentEvent.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("solutionId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["solutionId"] = "";
+        }
+        if (!("environmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["environmentId"] = "";
+        }
+        if (!("environmentName" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["environmentName"] = "";
+        }
+        if (!("kind" in $$source)) {
+            /**
+             * @member
+             * @type {DeploymentEventKind}
+             */
+            this["kind"] = (/** @type {DeploymentEventKind} */(""));
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+        if (!("changes" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleVersionChange[]}
+             */
+            this["changes"] = [];
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {EnvironmentModule[]}
+             */
+            this["modules"] = [];
+        }
+        if (!("configRevision" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["configRevision"] = 0;
+        }
+        if (!("author" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["author"] = "";
+        }
+        if (!("createdAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["createdAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new DeploymentEvent instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {DeploymentEvent}
+     */
+    static createFrom($$source = {}) {
+        const $$createField6_0 = $$createType19;
+        const $$createField7_0 = $$createType21;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("changes" in $$parsedSource) {
+            $$parsedSource["changes"] = $$createField6_0($$parsedSource["changes"]);
+        }
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField7_0($$parsedSource["modules"]);
+        }
+        return new DeploymentEvent(/** @type {Partial<DeploymentEvent>} */($$parsedSource));
+    }
+}
+
+/**
+ * DeploymentEventKind is what caused a deployment. Upgrades, downgrades and uninstalls
+ * only happen as part of promotions and rollbacks, so they don't have kinds of their own,
+ * see DeploymentEvent.Changes.
+ * @readonly
+ * @enum {string}
+ */
+export const DeploymentEventKind = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    DeploymentEventInstall: "install",
+    DeploymentEventConfig: "config",
+    DeploymentEventPromotion: "promotion",
+    DeploymentEventRollback: "rollback",
+    DeploymentEventStart: "start",
+    DeploymentEventStop: "stop",
+    DeploymentEventDelete: "delete",
+    DeploymentEventSync: "sync",
+};
+
+/**
+ * @readonly
+ * @enum {string}
+ */
+export const DiagnosticSeverity = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    DiagnosticSeverityError: "error",
+    DiagnosticSeverityWarning: "warning",
+};
+
+/**
+ * @readonly
+ * @enum {string}
+ */
+export const DriftKind = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    /**
+     * DriftMissing is a module the solution declares that isn't installed
+     */
+    DriftMissing: "missing",
+
+    /**
+     * DriftUndeclared is an installed module the solution doesn't declare
+     */
+    DriftUndeclared: "undeclared",
+
+    /**
+     * DriftVersion is a module installed in another version than the solution declares
+     */
+    DriftVersion: "version",
+
+    /**
+     * DriftConfig is a module whose values differ from the solution's configuration
+     */
+    DriftConfig: "config",
+};
+
+/**
+ * EffectiveConfig is the configuration an environment is deployed with, after merging all layers
+ */
+export class EffectiveConfig {
+    /**
+     * Creates a new EffectiveConfig instance.
+     * @param {Partial<EffectiveConfig>} [$$source = {}] - The source object to create the EffectiveConfig.
+     */
+    constructor($$source = {}) {
+        if (!("solutionId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["solutionId"] = "";
+        }
+        if (!("environmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["environmentId"] = "";
+        }
+        if (!("resourceProfile" in $$source)) {
+            /**
+             * @member
+             * @type {EffectiveValue}
+             */
+            this["resourceProfile"] = (new EffectiveValue());
+        }
+        if (!("global" in $$source)) {
+            /**
+             * @member
+             * @type {{ [_: string]: EffectiveValue }}
+             */
+            this["global"] = {};
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {{ [_: string]: { [_: string]: EffectiveValue } }}
+             */
+            this["modules"] = {};
+        }
+        if (!("resources" in $$source)) {
+            /**
+             * @member
+             * @type {ResourcePlan}
+             */
+            this["resources"] = (new ResourcePlan());
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new EffectiveConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {EffectiveConfig}
+     */
+    static createFrom($$source = {}) {
+        const $$createField2_0 = $$createType22;
+        const $$createField3_0 = $$createType23;
+        const $$createField4_0 = $$createType24;
+        const $$createField5_0 = $$createType25;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("resourceProfile" in $$parsedSource) {
+            $$parsedSource["resourceProfile"] = $$createField2_0($$parsedSource["resourceProfile"]);
+        }
+        if ("global" in $$parsedSource) {
+            $$parsedSource["global"] = $$createField3_0($$parsedSource["global"]);
+        }
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField4_0($$parsedSource["modules"]);
+        }
+        if ("resources" in $$parsedSource) {
+            $$parsedSource["resources"] = $$createField5_0($$parsedSource["resources"]);
+        }
+        return new EffectiveConfig(/** @type {Partial<EffectiveConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * EffectiveValue is a merged configuration value. Source is the layer that set it and
+ * Overrides lists the lower layers that also set it and were overridden.
+ */
+export class EffectiveValue {
+    /**
+     * Creates a new EffectiveValue instance.
+     * @param {Partial<EffectiveValue>} [$$source = {}] - The source object to create the EffectiveValue.
+     */
+    constructor($$source = {}) {
+        if (!("value" in $$source)) {
+            /**
+             * @member
+             * @type {any}
+             */
+            this["value"] = null;
+        }
+        if (!("source" in $$source)) {
+            /**
+             * @member
+             * @type {ConfigLayer}
+             */
+            this["source"] = (/** @type {ConfigLayer} */(""));
+        }
+        if (!("overrides" in $$source)) {
+            /**
+             * @member
+             * @type {ConfigLayer[]}
+             */
+            this["overrides"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new EffectiveValue instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {EffectiveValue}
+     */
+    static createFrom($$source = {}) {
+        const $$createField2_0 = $$createType26;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("overrides" in $$parsedSource) {
+            $$parsedSource["overrides"] = $$createField2_0($$parsedSource["overrides"]);
+        }
+        return new EffectiveValue(/** @type {Partial<EffectiveValue>} */($$parsedSource));
+    }
+}
+
+export class Environment {
+    /**
+     * Creates a new Environment instance.
+     * @param {Partial<Environment>} [$$source = {}] - The source object to create the Environment.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("namespace" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["namespace"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {EnvironmentStatus}
+             */
+            this["status"] = (/** @type {EnvironmentStatus} */(""));
+        }
+        if (!("lastDeployed" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["lastDeployed"] = null;
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {EnvironmentModule[]}
+             */
+            this["modules"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Environment instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {Environment}
+     */
+    static createFrom($$source = {}) {
+        const $$createField5_0 = $$createType21;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField5_0($$parsedSource["modules"]);
+        }
+        return new Environment(/** @type {Partial<Environment>} */($$parsedSource));
+    }
+}
+
+/**
+ * EnvironmentComparison compares all environments of a solution against each other and
+ * against the solution's declared modules and configuration
+ */
+export class EnvironmentComparison {
+    /**
+     * Creates a new EnvironmentComparison instance.
+     * @param {Partial<EnvironmentComparison>} [$$source = {}] - The source object to create the EnvironmentComparison.
+     */
+    constructor($$source = {}) {
+        if (!("solutionId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["solutionId"] = "";
+        }
+        if (!("environments" in $$source)) {
+            /**
+             * @member
+             * @type {EnvironmentSummary[]}
+             */
+            this["environments"] = [];
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleComparison[]}
+             */
+            this["modules"] = [];
+        }
+        if (!("config" in $$source)) {
+            /**
+             * @member
+             * @type {ConfigComparison[]}
+             */
+            this["config"] = [];
+        }
+        if (!("driftCount" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["driftCount"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new EnvironmentComparison instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {EnvironmentComparison}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType28;
+        const $$createField2_0 = $$createType30;
+        const $$createField3_0 = $$createType32;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("environments" in $$parsedSource) {
+            $$parsedSource["environments"] = $$createField1_0($$parsedSource["environments"]);
+        }
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField2_0($$parsedSource["modules"]);
+        }
+        if ("config" in $$parsedSource) {
+            $$parsedSource["config"] = $$createField3_0($$parsedSource["config"]);
+        }
+        return new EnvironmentComparison(/** @type {Partial<EnvironmentComparison>} */($$parsedSource));
+    }
+}
+
+/**
+ * EnvironmentConfig is the configuration of an environment. YAML is the document as
+ * stored, the other fields are the parsed values.
+ */
+export class EnvironmentConfig {
+    /**
+     * Creates a new EnvironmentConfig instance.
+     * @param {Partial<EnvironmentConfig>} [$$source = {}] - The source object to create the EnvironmentConfig.
+     */
+    constructor($$source = {}) {
+        if (!("solutionId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["solutionId"] = "";
+        }
+        if (!("environmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["environmentId"] = "";
+        }
+        if (!("yaml" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["yaml"] = "";
+        }
+        if (!("resourceProfile" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["resourceProfile"] = "";
+        }
+        if (!("global" in $$source)) {
+            /**
+             * @member
+             * @type {{ [_: string]: string }}
+             */
+            this["global"] = {};
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {{ [_: string]: { [_: string]: any } }}
+             */
+            this["modules"] = {};
+        }
+        if (!("saved" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["saved"] = false;
+        }
+        if (!("updatedAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["updatedAt"] = null;
+        }
+        if (!("revision" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["revision"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new EnvironmentConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {EnvironmentConfig}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType33;
+        const $$createField5_0 = $$createType34;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("global" in $$parsedSource) {
+            $$parsedSource["global"] = $$createField4_0($$parsedSource["global"]);
+        }
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField5_0($$parsedSource["modules"]);
+        }
+        return new EnvironmentConfig(/** @type {Partial<EnvironmentConfig>} */($$parsedSource));
+    }
+}
+
+export class EnvironmentModule {
+    /**
+     * Creates a new EnvironmentModule instance.
+     * @param {Partial<EnvironmentModule>} [$$source = {}] - The source object to create the EnvironmentModule.
+     */
+    constructor($$source = {}) {
+        if (!("moduleId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["moduleId"] = "";
+        }
+        if (!("version" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["version"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {EnvironmentStatus}
+             */
+            this["status"] = (/** @type {EnvironmentStatus} */(""));
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new EnvironmentModule instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {EnvironmentModule}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new EnvironmentModule(/** @type {Partial<EnvironmentModule>} */($$parsedSource));
+    }
+}
+
+/**
+ * EnvironmentStage is where an environment sits in the path from development to production
+ * @readonly
+ * @enum {string}
+ */
+export const EnvironmentStage = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    EnvironmentStageDevelopment: "development",
+    EnvironmentStageStaging: "staging",
+    EnvironmentStageProduction: "production",
+};
+
+/**
+ * @readonly
+ * @enum {string}
+ */
+export const EnvironmentStatus = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    EnvironmentStatusRunning: "running",
+    EnvironmentStatusStopped: "stopped",
+    EnvironmentStatusError: "error",
+
+    /**
+     * Transitional statuses while a lifecycle operation runs
+     */
+    EnvironmentStatusStarting: "starting",
+    EnvironmentStatusStopping: "stopping",
+    EnvironmentStatusDeleting: "deleting",
+    EnvironmentStatusSyncing: "syncing",
+    EnvironmentStatusDeploying: "deploying",
+};
+
+/**
+ * EnvironmentSummary is a column of the comparison matrix. ConfigDrift lists the paths
+ * outside of module values, such as the resource profile, that deviate from the baseline.
+ */
+export class EnvironmentSummary {
+    /**
+     * Creates a new EnvironmentSummary instance.
+     * @param {Partial<EnvironmentSummary>} [$$source = {}] - The source object to create the EnvironmentSummary.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("stage" in $$source)) {
+            /**
+             * @member
+             * @type {EnvironmentStage}
+             */
+            this["stage"] = (/** @type {EnvironmentStage} */(""));
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {EnvironmentStatus}
+             */
+            this["status"] = (/** @type {EnvironmentStatus} */(""));
+        }
+        if (!("configDrift" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["configDrift"] = [];
+        }
+        if (!("driftCount" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["driftCount"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new EnvironmentSummary instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {EnvironmentSummary}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType16;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("configDrift" in $$parsedSource) {
+            $$parsedSource["configDrift"] = $$createField4_0($$parsedSource["configDrift"]);
+        }
+        return new EnvironmentSummary(/** @type {Partial<EnvironmentSummary>} */($$parsedSource));
+    }
+}
+
+/**
+ * ErrorKind classifies lookup failures so the frontend can show an actionable message
+ * @readonly
+ * @enum {string}
+ */
+export const ErrorKind = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    ErrorKindNotFound: "not_found",
+    ErrorKindUnauthorized: "unauthorized",
+    ErrorKindRateLimited: "rate_limited",
+    ErrorKindNetwork: "network",
+    ErrorKindInvalid: "invalid",
+    ErrorKindUnknown: "unknown",
+};
+
+export class FacetValue {
+    /**
+     * Creates a new FacetValue instance.
+     * @param {Partial<FacetValue>} [$$source = {}] - The source object to create the FacetValue.
+     */
+    constructor($$source = {}) {
+        if (!("value" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["value"] = "";
+        }
+        if (!("label" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["label"] = "";
+        }
+        if (!("count" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["count"] = 0;
+        }
+        if (!("selected" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["selected"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new FacetValue instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {FacetValue}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new FacetValue(/** @type {Partial<FacetValue>} */($$parsedSource));
+    }
+}
+
+/**
+ * FieldError is a validation error for one field of a request, so the frontend can show it
+ * next to the input it belongs to
+ */
+export class FieldError {
+    /**
+     * Creates a new FieldError instance.
+     * @param {Partial<FieldError>} [$$source = {}] - The source object to create the FieldError.
+     */
+    constructor($$source = {}) {
+        if (!("field" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["field"] = "";
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new FieldError instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {FieldError}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new FieldError(/** @type {Partial<FieldError>} */($$parsedSource));
+    }
+}
+
+export class LogEntry {
+    /**
+     * Creates a new LogEntry instance.
+     * @param {Partial<LogEntry>} [$$source = {}] - The source object to create the LogEntry.
+     */
+    constructor($$source = {}) {
+        if (!("timestamp" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["timestamp"] = null;
+        }
+        if (!("level" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["level"] = "";
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new LogEntry instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {LogEntry}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new LogEntry(/** @type {Partial<LogEntry>} */($$parsedSource));
+    }
+}
+
+/**
+ * LookupError is returned when fetching data for a module fails. Wails only passes the
+ * error message to the frontend, so bindings that need the kind return it as part of
+ * their result instead.
+ */
+export class LookupError {
+    /**
+     * Creates a new LookupError instance.
+     * @param {Partial<LookupError>} [$$source = {}] - The source object to create the LookupError.
+     */
+    constructor($$source = {}) {
+        if (!("kind" in $$source)) {
+            /**
+             * @member
+             * @type {ErrorKind}
+             */
+            this["kind"] = (/** @type {ErrorKind} */(""));
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["resetAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new LookupError instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {LookupError}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new LookupError(/** @type {Partial<LookupError>} */($$parsedSource));
+    }
+}
+
+/**
+ * ManifestPreview holds the rendered charts of the modules of an environment
+ */
+export class ManifestPreview {
+    /**
+     * Creates a new ManifestPreview instance.
+     * @param {Partial<ManifestPreview>} [$$source = {}] - The source object to create the ManifestPreview.
+     */
+    constructor($$source = {}) {
+        if (!("solutionId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["solutionId"] = "";
+        }
+        if (!("environmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["environmentId"] = "";
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleManifests[]}
+             */
+            this["modules"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ManifestPreview instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ManifestPreview}
+     */
+    static createFrom($$source = {}) {
+        const $$createField2_0 = $$createType36;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField2_0($$parsedSource["modules"]);
+        }
+        return new ManifestPreview(/** @type {Partial<ManifestPreview>} */($$parsedSource));
+    }
+}
+
+export class ModuleAttributes {
+    /**
+     * Creates a new ModuleAttributes instance.
+     * @param {Partial<ModuleAttributes>} [$$source = {}] - The source object to create the ModuleAttributes.
+     */
+    constructor($$source = {}) {
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["githubRepo"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["documentation"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["website"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["license"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {{ [_: string]: string } | undefined}
+             */
+            this["packages"] = {};
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleAttributes instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleAttributes}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType33;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("packages" in $$parsedSource) {
+            $$parsedSource["packages"] = $$createField4_0($$parsedSource["packages"]);
+        }
+        return new ModuleAttributes(/** @type {Partial<ModuleAttributes>} */($$parsedSource));
+    }
+}
+
+/**
+ * ModuleChart is the Helm chart a module is deployed with. Path is a chart directory or
+ * archive, looked up in the charts bundled with the app before the file system. Charts in
+ * a repository set Repository to an oci:// registry or an http(s) chart repository along
+ * with Name and Version.
+ */
+export class ModuleChart {
+    /**
+     * Creates a new ModuleChart instance.
+     * @param {Partial<ModuleChart>} [$$source = {}] - The source object to create the ModuleChart.
+     */
+    constructor($$source = {}) {
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["path"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["repository"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["name"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["version"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleChart instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleChart}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ModuleChart(/** @type {Partial<ModuleChart>} */($$parsedSource));
+    }
+}
+
+/**
+ * ModuleComparison is a row of the comparison matrix. BaselineVersion is the version in
+ * Solution.Modules, empty if the solution doesn't declare the module.
+ */
+export class ModuleComparison {
+    /**
+     * Creates a new ModuleComparison instance.
+     * @param {Partial<ModuleComparison>} [$$source = {}] - The source object to create the ModuleComparison.
+     */
+    constructor($$source = {}) {
+        if (!("moduleId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["moduleId"] = "";
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("declared" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["declared"] = false;
+        }
+        if (!("baselineVersion" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["baselineVersion"] = "";
+        }
+        if (!("environments" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleEnvironmentState[]}
+             */
+            this["environments"] = [];
+        }
+        if (!("drifted" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["drifted"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleComparison instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleComparison}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType38;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("environments" in $$parsedSource) {
+            $$parsedSource["environments"] = $$createField4_0($$parsedSource["environments"]);
+        }
+        return new ModuleComparison(/** @type {Partial<ModuleComparison>} */($$parsedSource));
+    }
+}
+
+export class ModuleComponent {
+    /**
+     * Creates a new ModuleComponent instance.
+     * @param {Partial<ModuleComponent>} [$$source = {}] - The source object to create the ModuleComponent.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("type" in $$source)) {
+            /**
+             * @member
+             * @type {ComponentType}
+             */
+            this["type"] = (/** @type {ComponentType} */(""));
+        }
+        if (!("description" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["description"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleComponent instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleComponent}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ModuleComponent(/** @type {Partial<ModuleComponent>} */($$parsedSource));
+    }
+}
+
+export class ModuleDependency {
+    /**
+     * Creates a new ModuleDependency instance.
+     * @param {Partial<ModuleDependency>} [$$source = {}] - The source object to create the ModuleDependency.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("version" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["version"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleDependency instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleDependency}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ModuleDependency(/** @type {Partial<ModuleDependency>} */($$parsedSource));
+    }
+}
+
+export class ModuleDiagnostics {
+    /**
+     * Creates a new ModuleDiagnostics instance.
+     * @param {Partial<ModuleDiagnostics>} [$$source = {}] - The source object to create the ModuleDiagnostics.
+     */
+    constructor($$source = {}) {
+        if (!("moduleId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["moduleId"] = "";
+        }
+        if (!("diagnostics" in $$source)) {
+            /**
+             * @member
+             * @type {CatalogDiagnostic[]}
+             */
+            this["diagnostics"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleDiagnostics instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleDiagnostics}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType40;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("diagnostics" in $$parsedSource) {
+            $$parsedSource["diagnostics"] = $$createField1_0($$parsedSource["diagnostics"]);
+        }
+        return new ModuleDiagnostics(/** @type {Partial<ModuleDiagnostics>} */($$parsedSource));
+    }
+}
+
+/**
+ * ModuleEnvironmentState is a cell of the comparison matrix: a module in one environment.
+ * ConfigDrift lists the configuration paths where the environment deviates from the baseline.
+ */
+export class ModuleEnvironmentState {
+    /**
+     * Creates a new ModuleEnvironmentState instance.
+     * @param {Partial<ModuleEnvironmentState>} [$$source = {}] - The source object to create the ModuleEnvironmentState.
+     */
+    constructor($$source = {}) {
+        if (!("environmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["environmentId"] = "";
+        }
+        if (!("installed" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["installed"] = false;
+        }
+        if (!("version" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["version"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {EnvironmentStatus}
+             */
+            this["status"] = (/** @type {EnvironmentStatus} */(""));
+        }
+        if (!("drift" in $$source)) {
+            /**
+             * @member
+             * @type {DriftKind[]}
+             */
+            this["drift"] = [];
+        }
+        if (!("configDrift" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["configDrift"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleEnvironmentState instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleEnvironmentState}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType41;
+        const $$createField5_0 = $$createType16;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("drift" in $$parsedSource) {
+            $$parsedSource["drift"] = $$createField4_0($$parsedSource["drift"]);
+        }
+        if ("configDrift" in $$parsedSource) {
+            $$parsedSource["configDrift"] = $$createField5_0($$parsedSource["configDrift"]);
+        }
+        return new ModuleEnvironmentState(/** @type {Partial<ModuleEnvironmentState>} */($$parsedSource));
+    }
+}
+
+export class ModuleFacets {
+    /**
+     * Creates a new ModuleFacets instance.
+     * @param {Partial<ModuleFacets>} [$$source = {}] - The source object to create the ModuleFacets.
+     */
+    constructor($$source = {}) {
+        if (!("organizations" in $$source)) {
+            /**
+             * @member
+             * @type {FacetValue[]}
+             */
+            this["organizations"] = [];
+        }
+        if (!("maintainers" in $$source)) {
+            /**
+             * @member
+             * @type {FacetValue[]}
+             */
+            this["maintainers"] = [];
+        }
+        if (!("licenses" in $$source)) {
+            /**
+             * @member
+             * @type {FacetValue[]}
+             */
+            this["licenses"] = [];
+        }
+        if (!("tags" in $$source)) {
+            /**
+             * @member
+             * @type {FacetValue[]}
+             */
+            this["tags"] = [];
+        }
+        if (!("componentTypes" in $$source)) {
+            /**
+             * @member
+             * @type {FacetValue[]}
+             */
+            this["componentTypes"] = [];
+        }
+        if (!("solutions" in $$source)) {
+            /**
+             * @member
+             * @type {FacetValue[]}
+             */
+            this["solutions"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleFacets instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleFacets}
+     */
+    static createFrom($$source = {}) {
+        const $$createField0_0 = $$createType43;
+        const $$createField1_0 = $$createType43;
+        const $$createField2_0 = $$createType43;
+        const $$createField3_0 = $$createType43;
+        const $$createField4_0 = $$createType43;
+        const $$createField5_0 = $$createType43;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("organizations" in $$parsedSource) {
+            $$parsedSource["organizations"] = $$createField0_0($$parsedSource["organizations"]);
+        }
+        if ("maintainers" in $$parsedSource) {
+            $$parsedSource["maintainers"] = $$createField1_0($$parsedSource["maintainers"]);
+        }
+        if ("licenses" in $$parsedSource) {
+            $$parsedSource["licenses"] = $$createField2_0($$parsedSource["licenses"]);
+        }
+        if ("tags" in $$parsedSource) {
+            $$parsedSource["tags"] = $$createField3_0($$parsedSource["tags"]);
+        }
+        if ("componentTypes" in $$parsedSource) {
+            $$parsedSource["componentTypes"] = $$createField4_0($$parsedSource["componentTypes"]);
+        }
+        if ("solutions" in $$parsedSource) {
+            $$parsedSource["solutions"] = $$createField5_0($$parsedSource["solutions"]);
+        }
+        return new ModuleFacets(/** @type {Partial<ModuleFacets>} */($$parsedSource));
+    }
+}
+
+/**
+ * ModuleFilter narrows down the module catalog. Values within one facet are OR'ed,
+ * different facets are AND'ed. Empty facets don't filter.
+ */
+export class ModuleFilter {
+    /**
+     * Creates a new ModuleFilter instance.
+     * @param {Partial<ModuleFilter>} [$$source = {}] - The source object to create the ModuleFilter.
+     */
+    constructor($$source = {}) {
+        if (!("query" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["query"] = "";
+        }
+        if (!("organizations" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["organizations"] = [];
+        }
+        if (!("maintainers" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["maintainers"] = [];
+        }
+        if (!("licenses" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["licenses"] = [];
+        }
+        if (!("tags" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["tags"] = [];
+        }
+        if (!("componentTypes" in $$source)) {
+            /**
+             * @member
+             * @type {ComponentType[]}
+             */
+            this["componentTypes"] = [];
+        }
+        if (!("solutionIds" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["solutionIds"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleFilter instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleFilter}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType16;
+        const $$createField2_0 = $$createType16;
+        const $$createField3_0 = $$createType16;
+        const $$createField4_0 = $$createType16;
+        const $$createField5_0 = $$createType44;
+        const $$createField6_0 = $$createType16;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("organizations" in $$parsedSource) {
+            $$parsedSource["organizations"] = $$createField1_0($$parsedSource["organizations"]);
+        }
+        if ("maintainers" in $$parsedSource) {
+            $$parsedSource["maintainers"] = $$createField2_0($$parsedSource["maintainers"]);
+        }
+        if ("licenses" in $$parsedSource) {
+            $$parsedSource["licenses"] = $$createField3_0($$parsedSource["licenses"]);
+        }
+        if ("tags" in $$parsedSource) {
+            $$parsedSource["tags"] = $$createField4_0($$parsedSource["tags"]);
+        }
+        if ("componentTypes" in $$parsedSource) {
+            $$parsedSource["componentTypes"] = $$createField5_0($$parsedSource["componentTypes"]);
+        }
+        if ("solutionIds" in $$parsedSource) {
+            $$parsedSource["solutionIds"] = $$createField6_0($$parsedSource["solutionIds"]);
+        }
+        return new ModuleFilter(/** @type {Partial<ModuleFilter>} */($$parsedSource));
+    }
+}
+
+/**
+ * ModuleManifests are the manifests a module's chart renders to in an environment. Chart
+ * is the name and version of the chart that was rendered. Error is set instead of the
+ * manifests if the chart couldn't be fetched or rendered.
+ */
+export class ModuleManifests {
+    /**
+     * Creates a new ModuleManifests instance.
+     * @param {Partial<ModuleManifests>} [$$source = {}] - The source object to create the ModuleManifests.
+     */
+    constructor($$source = {}) {
+        if (!("moduleId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["moduleId"] = "";
+        }
+        if (!("release" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["release"] = "";
+        }
+        if (!("chart" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["chart"] = "";
+        }
+        if (!("manifests" in $$source)) {
+            /**
+             * @member
+             * @type {RenderedManifest[]}
+             */
+            this["manifests"] = [];
+        }
+        if (!("error" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["error"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleManifests instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleManifests}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType46;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("manifests" in $$parsedSource) {
+            $$parsedSource["manifests"] = $$createField3_0($$parsedSource["manifests"]);
+        }
+        return new ModuleManifests(/** @type {Partial<ModuleManifests>} */($$parsedSource));
+    }
+}
+
+/**
+ * ModulePromotion is what happens to a single module in the target environment.
+ * ConfigChanges are the changes to the module's values in the target's configuration.
+ */
+export class ModulePromotion {
+    /**
+     * Creates a new ModulePromotion instance.
+     * @param {Partial<ModulePromotion>} [$$source = {}] - The source object to create the ModulePromotion.
+     */
+    constructor($$source = {}) {
+        if (!("moduleId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["moduleId"] = "";
+        }
+        if (!("action" in $$source)) {
+            /**
+             * @member
+             * @type {PromotionAction}
+             */
+            this["action"] = (/** @type {PromotionAction} */(""));
+        }
+        if (!("fromVersion" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["fromVersion"] = "";
+        }
+        if (!("toVersion" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["toVersion"] = "";
+        }
+        if (!("configChanges" in $$source)) {
+            /**
+             * @member
+             * @type {ConfigChange[]}
+             */
+            this["configChanges"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModulePromotion instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModulePromotion}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType8;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("configChanges" in $$parsedSource) {
+            $$parsedSource["configChanges"] = $$createField4_0($$parsedSource["configChanges"]);
+        }
+        return new ModulePromotion(/** @type {Partial<ModulePromotion>} */($$parsedSource));
+    }
+}
+
+export class ModuleQueryResult {
+    /**
+     * Creates a new ModuleQueryResult instance.
+     * @param {Partial<ModuleQueryResult>} [$$source = {}] - The source object to create the ModuleQueryResult.
+     */
+    constructor($$source = {}) {
+        if (!("results" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleSearchResult[]}
+             */
+            this["results"] = [];
+        }
+        if (!("total" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["total"] = 0;
+        }
+        if (!("facets" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleFacets}
+             */
+            this["facets"] = (new ModuleFacets());
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleQueryResult instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleQueryResult}
+     */
+    static createFrom($$source = {}) {
+        const $$createField0_0 = $$createType48;
+        const $$createField2_0 = $$createType49;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("results" in $$parsedSource) {
+            $$parsedSource["results"] = $$createField0_0($$parsedSource["results"]);
+        }
+        if ("facets" in $$parsedSource) {
+            $$parsedSource["facets"] = $$createField2_0($$parsedSource["facets"]);
+        }
+        return new ModuleQueryResult(/** @type {Partial<ModuleQueryResult>} */($$parsedSource));
+    }
+}
+
+/**
+ * ModuleReadme is the rendered README of a module, or the reason it couldn't be loaded
+ */
+export class ModuleReadme {
+    /**
+     * Creates a new ModuleReadme instance.
+     * @param {Partial<ModuleReadme>} [$$source = {}] - The source object to create the ModuleReadme.
+     */
+    constructor($$source = {}) {
+        if (!("html" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["html"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {LookupError | null | undefined}
+             */
+            this["error"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleReadme instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleReadme}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType51;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("error" in $$parsedSource) {
+            $$parsedSource["error"] = $$createField1_0($$parsedSource["error"]);
+        }
+        return new ModuleReadme(/** @type {Partial<ModuleReadme>} */($$parsedSource));
+    }
+}
+
+/**
+ * ModuleResponse is used for API responses to ensure consistent JSON serialization
+ */
+export class ModuleResponse {
+    /**
+     * Creates a new ModuleResponse instance.
+     * @param {Partial<ModuleResponse>} [$$source = {}] - The source object to create the ModuleResponse.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("description" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["description"] = "";
         }
         if (!("organization" in $$source)) {
             /**
              * @member
-             * @type {string}
+             * @type {string}
+             */
+            this["organization"] = "";
+        }
+        if (!("lastUpdated" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["lastUpdated"] = null;
+        }
+        if (!("tags" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["tags"] = [];
+        }
+        if (!("version" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["version"] = "";
+        }
+        if (!("maintainer" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["maintainer"] = "";
+        }
+        if (!("dependencies" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleDependency[]}
+             */
+            this["dependencies"] = [];
+        }
+        if (!("attributes" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleAttributes}
+             */
+            this["attributes"] = (new ModuleAttributes());
+        }
+        if (!("components" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleComponent[]}
+             */
+            this["components"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {json$0.RawMessage | undefined}
+             */
+            this["configSchema"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {ModuleChart | null | undefined}
+             */
+            this["chart"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleResponse instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleResponse}
+     */
+    static createFrom($$source = {}) {
+        const $$createField5_0 = $$createType16;
+        const $$createField8_0 = $$createType53;
+        const $$createField9_0 = $$createType54;
+        const $$createField10_0 = $$createType56;
+        const $$createField12_0 = $$createType58;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("tags" in $$parsedSource) {
+            $$parsedSource["tags"] = $$createField5_0($$parsedSource["tags"]);
+        }
+        if ("dependencies" in $$parsedSource) {
+            $$parsedSource["dependencies"] = $$createField8_0($$parsedSource["dependencies"]);
+        }
+        if ("attributes" in $$parsedSource) {
+            $$parsedSource["attributes"] = $$createField9_0($$parsedSource["attributes"]);
+        }
+        if ("components" in $$parsedSource) {
+            $$parsedSource["components"] = $$createField10_0($$parsedSource["components"]);
+        }
+        if ("chart" in $$parsedSource) {
+            $$parsedSource["chart"] = $$createField12_0($$parsedSource["chart"]);
+        }
+        return new ModuleResponse(/** @type {Partial<ModuleResponse>} */($$parsedSource));
+    }
+}
+
+export class ModuleSearchResult {
+    /**
+     * Creates a new ModuleSearchResult instance.
+     * @param {Partial<ModuleSearchResult>} [$$source = {}] - The source object to create the ModuleSearchResult.
+     */
+    constructor($$source = {}) {
+        if (!("module" in $$source)) {
+            /**
+             * @member
+             * @type {ModuleResponse}
+             */
+            this["module"] = (new ModuleResponse());
+        }
+        if (!("score" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["score"] = 0;
+        }
+        if (!("highlights" in $$source)) {
+            /**
+             * @member
+             * @type {SearchHighlight[]}
+             */
+            this["highlights"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleSearchResult instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleSearchResult}
+     */
+    static createFrom($$source = {}) {
+        const $$createField0_0 = $$createType59;
+        const $$createField2_0 = $$createType61;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("module" in $$parsedSource) {
+            $$parsedSource["module"] = $$createField0_0($$parsedSource["module"]);
+        }
+        if ("highlights" in $$parsedSource) {
+            $$parsedSource["highlights"] = $$createField2_0($$parsedSource["highlights"]);
+        }
+        return new ModuleSearchResult(/** @type {Partial<ModuleSearchResult>} */($$parsedSource));
+    }
+}
+
+/**
+ * ModuleVersionChange is a module whose version changed in a deployment. Before is empty
+ * for an install, After for an uninstall.
+ */
+export class ModuleVersionChange {
+    /**
+     * Creates a new ModuleVersionChange instance.
+     * @param {Partial<ModuleVersionChange>} [$$source = {}] - The source object to create the ModuleVersionChange.
+     */
+    constructor($$source = {}) {
+        if (!("moduleId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["moduleId"] = "";
+        }
+        if (!("before" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["before"] = "";
+        }
+        if (!("after" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["after"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ModuleVersionChange instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ModuleVersionChange}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ModuleVersionChange(/** @type {Partial<ModuleVersionChange>} */($$parsedSource));
+    }
+}
+
+/**
+ * Operation is a change that runs in the background, such as deploying to an environment.
+ * Progress is the percentage of steps that are done.
+ */
+export class Operation {
+    /**
+     * Creates a new Operation instance.
+     * @param {Partial<Operation>} [$$source = {}] - The source object to create the Operation.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("kind" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["kind"] = "";
+        }
+        if (!("title" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["title"] = "";
+        }
+        if (!("solutionId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["solutionId"] = "";
+        }
+        if (!("environmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["environmentId"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {OperationStatus}
+             */
+            this["status"] = (/** @type {OperationStatus} */(""));
+        }
+        if (!("progress" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["progress"] = 0;
+        }
+        if (!("steps" in $$source)) {
+            /**
+             * @member
+             * @type {OperationStep[]}
+             */
+            this["steps"] = [];
+        }
+        if (!("error" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["error"] = "";
+        }
+        if (!("createdAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["createdAt"] = null;
+        }
+        if (!("updatedAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["updatedAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Operation instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {Operation}
+     */
+    static createFrom($$source = {}) {
+        const $$createField7_0 = $$createType63;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("steps" in $$parsedSource) {
+            $$parsedSource["steps"] = $$createField7_0($$parsedSource["steps"]);
+        }
+        return new Operation(/** @type {Partial<Operation>} */($$parsedSource));
+    }
+}
+
+/**
+ * @readonly
+ * @enum {string}
+ */
+export const OperationStatus = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    OperationStatusPending: "pending",
+    OperationStatusRunning: "running",
+    OperationStatusSucceeded: "succeeded",
+    OperationStatusFailed: "failed",
+    OperationStatusCancelled: "cancelled",
+};
+
+export class OperationStep {
+    /**
+     * Creates a new OperationStep instance.
+     * @param {Partial<OperationStep>} [$$source = {}] - The source object to create the OperationStep.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {OperationStatus}
+             */
+            this["status"] = (/** @type {OperationStatus} */(""));
+        }
+        if (!("error" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["error"] = "";
+        }
+        if (!("startedAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["startedAt"] = null;
+        }
+        if (!("finishedAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["finishedAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new OperationStep instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {OperationStep}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new OperationStep(/** @type {Partial<OperationStep>} */($$parsedSource));
+    }
+}
+
+/**
+ * @readonly
+ * @enum {string}
+ */
+export const PromotionAction = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    PromotionActionInstall: "install",
+    PromotionActionUpgrade: "upgrade",
+    PromotionActionDowngrade: "downgrade",
+    PromotionActionUninstall: "uninstall",
+    PromotionActionUnchanged: "unchanged",
+};
+
+/**
+ * PromotionPlan describes what promoting modules from one environment to another would
+ * change. A plan with violations is blocked and can't be applied.
+ */
+export class PromotionPlan {
+    /**
+     * Creates a new PromotionPlan instance.
+     * @param {Partial<PromotionPlan>} [$$source = {}] - The source object to create the PromotionPlan.
+     */
+    constructor($$source = {}) {
+        if (!("solutionId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["solutionId"] = "";
+        }
+        if (!("fromEnvironmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["fromEnvironmentId"] = "";
+        }
+        if (!("toEnvironmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["toEnvironmentId"] = "";
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {ModulePromotion[]}
+             */
+            this["modules"] = [];
+        }
+        if (!("violations" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["violations"] = [];
+        }
+        if (!("blocked" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["blocked"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new PromotionPlan instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {PromotionPlan}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType65;
+        const $$createField4_0 = $$createType16;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField3_0($$parsedSource["modules"]);
+        }
+        if ("violations" in $$parsedSource) {
+            $$parsedSource["violations"] = $$createField4_0($$parsedSource["violations"]);
+        }
+        return new PromotionPlan(/** @type {Partial<PromotionPlan>} */($$parsedSource));
+    }
+}
+
+/**
+ * PromotionRecord is a promotion that was applied
+ */
+export class PromotionRecord {
+    /**
+     * Creates a new PromotionRecord instance.
+     * @param {Partial<PromotionRecord>} [$$source = {}] - The source object to create the PromotionRecord.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("fromEnvironmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["fromEnvironmentId"] = "";
+        }
+        if (!("toEnvironmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["toEnvironmentId"] = "";
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {ModulePromotion[]}
+             */
+            this["modules"] = [];
+        }
+        if (!("configRevision" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["configRevision"] = 0;
+        }
+        if (!("author" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["author"] = "";
+        }
+        if (!("promotedAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["promotedAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new PromotionRecord instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {PromotionRecord}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType65;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField3_0($$parsedSource["modules"]);
+        }
+        return new PromotionRecord(/** @type {Partial<PromotionRecord>} */($$parsedSource));
+    }
+}
+
+/**
+ * RenderedManifest is the output of one template of a chart
+ */
+export class RenderedManifest {
+    /**
+     * Creates a new RenderedManifest instance.
+     * @param {Partial<RenderedManifest>} [$$source = {}] - The source object to create the RenderedManifest.
+     */
+    constructor($$source = {}) {
+        if (!("template" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["template"] = "";
+        }
+        if (!("content" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["content"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RenderedManifest instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {RenderedManifest}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new RenderedManifest(/** @type {Partial<RenderedManifest>} */($$parsedSource));
+    }
+}
+
+/**
+ * ResourceIssue is a problem with the resources of an environment. Path is the dotted
+ * path of the configuration key that causes it.
+ */
+export class ResourceIssue {
+    /**
+     * Creates a new ResourceIssue instance.
+     * @param {Partial<ResourceIssue>} [$$source = {}] - The source object to create the ResourceIssue.
+     */
+    constructor($$source = {}) {
+        if (!("path" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["path"] = "";
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ResourceIssue instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ResourceIssue}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ResourceIssue(/** @type {Partial<ResourceIssue>} */($$parsedSource));
+    }
+}
+
+/**
+ * ResourcePlan is what all components of an environment are deployed with. Requests,
+ * Limits and Pods are the totals over all replicas, Quota is the limits the environment
+ * sets for itself in spec.quota.
+ */
+export class ResourcePlan {
+    /**
+     * Creates a new ResourcePlan instance.
+     * @param {Partial<ResourcePlan>} [$$source = {}] - The source object to create the ResourcePlan.
+     */
+    constructor($$source = {}) {
+        if (!("profile" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["profile"] = "";
+        }
+        if (!("components" in $$source)) {
+            /**
+             * @member
+             * @type {ComponentResourcePlan[]}
+             */
+            this["components"] = [];
+        }
+        if (!("requests" in $$source)) {
+            /**
+             * @member
+             * @type {ResourceQuantities}
+             */
+            this["requests"] = (new ResourceQuantities());
+        }
+        if (!("limits" in $$source)) {
+            /**
+             * @member
+             * @type {ResourceQuantities}
+             */
+            this["limits"] = (new ResourceQuantities());
+        }
+        if (!("pods" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["pods"] = 0;
+        }
+        if (!("quota" in $$source)) {
+            /**
+             * @member
+             * @type {{ [_: string]: string }}
+             */
+            this["quota"] = {};
+        }
+        if (!("issues" in $$source)) {
+            /**
+             * @member
+             * @type {ResourceIssue[]}
+             */
+            this["issues"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ResourcePlan instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ResourcePlan}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType67;
+        const $$createField2_0 = $$createType5;
+        const $$createField3_0 = $$createType5;
+        const $$createField5_0 = $$createType33;
+        const $$createField6_0 = $$createType69;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("components" in $$parsedSource) {
+            $$parsedSource["components"] = $$createField1_0($$parsedSource["components"]);
+        }
+        if ("requests" in $$parsedSource) {
+            $$parsedSource["requests"] = $$createField2_0($$parsedSource["requests"]);
+        }
+        if ("limits" in $$parsedSource) {
+            $$parsedSource["limits"] = $$createField3_0($$parsedSource["limits"]);
+        }
+        if ("quota" in $$parsedSource) {
+            $$parsedSource["quota"] = $$createField5_0($$parsedSource["quota"]);
+        }
+        if ("issues" in $$parsedSource) {
+            $$parsedSource["issues"] = $$createField6_0($$parsedSource["issues"]);
+        }
+        return new ResourcePlan(/** @type {Partial<ResourcePlan>} */($$parsedSource));
+    }
+}
+
+/**
+ * ResourceProfile is a named set of resources for each type of component
+ */
+export class ResourceProfile {
+    /**
+     * Creates a new ResourceProfile instance.
+     * @param {Partial<ResourceProfile>} [$$source = {}] - The source object to create the ResourceProfile.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("label" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["label"] = "";
+        }
+        if (!("description" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["description"] = "";
+        }
+        if (!("components" in $$source)) {
+            /**
+             * @member
+             * @type {{ [_: string]: ComponentResources }}
+             */
+            this["components"] = {};
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ResourceProfile instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ResourceProfile}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType71;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("components" in $$parsedSource) {
+            $$parsedSource["components"] = $$createField3_0($$parsedSource["components"]);
+        }
+        return new ResourceProfile(/** @type {Partial<ResourceProfile>} */($$parsedSource));
+    }
+}
+
+/**
+ * ResourceQuantities are CPU and memory amounts in Kubernetes notation, e.g. 500m and 512Mi
+ */
+export class ResourceQuantities {
+    /**
+     * Creates a new ResourceQuantities instance.
+     * @param {Partial<ResourceQuantities>} [$$source = {}] - The source object to create the ResourceQuantities.
+     */
+    constructor($$source = {}) {
+        if (!("cpu" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["cpu"] = "";
+        }
+        if (!("memory" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["memory"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ResourceQuantities instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ResourceQuantities}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ResourceQuantities(/** @type {Partial<ResourceQuantities>} */($$parsedSource));
+    }
+}
+
+/**
+ * RollbackPlan describes what rolling an environment back to a snapshot in its deployment
+ * history would change. Modules is the reverse of what happened since, ConfigRevision the
+ * configuration revision that is restored, 0 for the default configuration. A plan with
+ * violations, such as changes the environment's policies don't allow, is blocked and can't
+ * be applied.
+ */
+export class RollbackPlan {
+    /**
+     * Creates a new RollbackPlan instance.
+     * @param {Partial<RollbackPlan>} [$$source = {}] - The source object to create the RollbackPlan.
+     */
+    constructor($$source = {}) {
+        if (!("solutionId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["solutionId"] = "";
+        }
+        if (!("environmentId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["environmentId"] = "";
+        }
+        if (!("snapshotId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["snapshotId"] = "";
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {ModulePromotion[]}
              */
-            this["organization"] = "";
+            this["modules"] = [];
         }
-        if (!("lastUpdated" in $$source)) {
+        if (!("configRevision" in $$source)) {
             /**
              * @member
-             * @type {time$0.Time}
+             * @type {number}
              */
-            this["lastUpdated"] = null;
+            this["configRevision"] = 0;
         }
-        if (!("tags" in $$source)) {
+        if (!("configChanges" in $$source)) {
+            /**
+             * @member
+             * @type {ConfigChange[]}
+             */
+            this["configChanges"] = [];
+        }
+        if (!("violations" in $$source)) {
             /**
              * @member
              * @type {string[]}
              */
-            this["tags"] = [];
+            this["violations"] = [];
         }
-        if (!("version" in $$source)) {
+        if (!("blocked" in $$source)) {
             /**
              * @member
-             * @type {string}
+             * @type {boolean}
              */
-            this["version"] = "";
+            this["blocked"] = false;
         }
-        if (!("maintainer" in $$source)) {
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RollbackPlan instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {RollbackPlan}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType65;
+        const $$createField5_0 = $$createType8;
+        const $$createField6_0 = $$createType16;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField3_0($$parsedSource["modules"]);
+        }
+        if ("configChanges" in $$parsedSource) {
+            $$parsedSource["configChanges"] = $$createField5_0($$parsedSource["configChanges"]);
+        }
+        if ("violations" in $$parsedSource) {
+            $$parsedSource["violations"] = $$createField6_0($$parsedSource["violations"]);
+        }
+        return new RollbackPlan(/** @type {Partial<RollbackPlan>} */($$parsedSource));
+    }
+}
+
+/**
+ * SearchField identifies the part of a module a search hit came from
+ * @readonly
+ * @enum {string}
+ */
+export const SearchField = {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero: "",
+
+    SearchFieldName: "name",
+    SearchFieldTags: "tags",
+    SearchFieldComponents: "components",
+    SearchFieldMaintainer: "maintainer",
+    SearchFieldDescription: "description",
+    SearchFieldReadme: "readme",
+};
+
+/**
+ * SearchHighlight is a snippet of a matching field. Snippet is HTML escaped with
+ * the matched terms wrapped in <mark>.
+ */
+export class SearchHighlight {
+    /**
+     * Creates a new SearchHighlight instance.
+     * @param {Partial<SearchHighlight>} [$$source = {}] - The source object to create the SearchHighlight.
+     */
+    constructor($$source = {}) {
+        if (!("field" in $$source)) {
             /**
              * @member
-             * @type {string}
+             * @type {SearchField}
              */
-            this["maintainer"] = "";
+            this["field"] = (/** @type {SearchField} */(""));
         }
-        if (!("dependencies" in $$source)) {
+        if (!("snippet" in $$source)) {
             /**
              * @member
-             * @type {ModuleDependency[]}
+             * @type {string}
              */
-            this["dependencies"] = [];
+            this["snippet"] = "";
         }
-        if (!("attributes" in $$source)) {
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new SearchHighlight instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {SearchHighlight}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new SearchHighlight(/** @type {Partial<SearchHighlight>} */($$parsedSource));
+    }
+}
+
+/**
+ * SecretInfo describes a secret in the local vault. Values are never returned.
+ */
+export class SecretInfo {
+    /**
+     * Creates a new SecretInfo instance.
+     * @param {Partial<SecretInfo>} [$$source = {}] - The source object to create the SecretInfo.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
             /**
              * @member
-             * @type {ModuleAttributes}
+             * @type {string}
              */
-            this["attributes"] = (new ModuleAttributes());
+            this["name"] = "";
         }
-        if (!("components" in $$source)) {
+        if (!("updatedAt" in $$source)) {
             /**
              * @member
-             * @type {ModuleComponent[]}
+             * @type {time$0.Time}
              */
-            this["components"] = [];
+            this["updatedAt"] = null;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new ModuleResponse instance from a string or object.
+     * Creates a new SecretInfo instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {ModuleResponse}
+     * @returns {SecretInfo}
      */
     static createFrom($$source = {}) {
-        const $$createField5_0 = $$createType3;
-        const $$createField8_0 = $$createType5;
-        const $$createField9_0 = $$createType6;
-        const $$createField10_0 = $$createType8;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        if ("tags" in $$parsedSource) {
-            $$parsedSource["tags"] = $$createField5_0($$parsedSource["tags"]);
-        }
-        if ("dependencies" in $$parsedSource) {
-            $$parsedSource["dependencies"] = $$createField8_0($$parsedSource["dependencies"]);
-        }
-        if ("attributes" in $$parsedSource) {
-            $$parsedSource["attributes"] = $$createField9_0($$parsedSource["attributes"]);
-        }
-        if ("components" in $$parsedSource) {
-            $$parsedSource["components"] = $$createField10_0($$parsedSource["components"]);
-        }
-        return new ModuleResponse(/** @type {Partial<ModuleResponse>} */($$parsedSource));
+        return new SecretInfo(/** @type {Partial<SecretInfo>} */($$parsedSource));
     }
 }
 
@@ -567,8 +3764,8 @@ export class Solution {
      * @returns {Solution}
      */
     static createFrom($$source = {}) {
-        const $$createField6_0 = $$createType10;
-        const $$createField7_0 = $$createType12;
+        const $$createField6_0 = $$createType73;
+        const $$createField7_0 = $$createType74;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
         if ("modules" in $$parsedSource) {
             $$parsedSource["modules"] = $$createField6_0($$parsedSource["modules"]);
@@ -580,6 +3777,137 @@ export class Solution {
     }
 }
 
+/**
+ * SolutionConfig holds the values shared by all environments of a solution
+ */
+export class SolutionConfig {
+    /**
+     * Creates a new SolutionConfig instance.
+     * @param {Partial<SolutionConfig>} [$$source = {}] - The source object to create the SolutionConfig.
+     */
+    constructor($$source = {}) {
+        if (!("solutionId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["solutionId"] = "";
+        }
+        if (!("yaml" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["yaml"] = "";
+        }
+        if (!("resourceProfile" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["resourceProfile"] = "";
+        }
+        if (!("global" in $$source)) {
+            /**
+             * @member
+             * @type {{ [_: string]: string }}
+             */
+            this["global"] = {};
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {{ [_: string]: { [_: string]: any } }}
+             */
+            this["modules"] = {};
+        }
+        if (!("saved" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["saved"] = false;
+        }
+        if (!("updatedAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["updatedAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new SolutionConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {SolutionConfig}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType33;
+        const $$createField4_0 = $$createType34;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("global" in $$parsedSource) {
+            $$parsedSource["global"] = $$createField3_0($$parsedSource["global"]);
+        }
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField4_0($$parsedSource["modules"]);
+        }
+        return new SolutionConfig(/** @type {Partial<SolutionConfig>} */($$parsedSource));
+    }
+}
+
+export class SolutionConfigSaveResult {
+    /**
+     * Creates a new SolutionConfigSaveResult instance.
+     * @param {Partial<SolutionConfigSaveResult>} [$$source = {}] - The source object to create the SolutionConfigSaveResult.
+     */
+    constructor($$source = {}) {
+        if (!("saved" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["saved"] = false;
+        }
+        if (!("config" in $$source)) {
+            /**
+             * @member
+             * @type {SolutionConfig}
+             */
+            this["config"] = (new SolutionConfig());
+        }
+        if (!("issues" in $$source)) {
+            /**
+             * @member
+             * @type {ConfigIssue[]}
+             */
+            this["issues"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new SolutionConfigSaveResult instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {SolutionConfigSaveResult}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType75;
+        const $$createField2_0 = $$createType11;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("config" in $$parsedSource) {
+            $$parsedSource["config"] = $$createField1_0($$parsedSource["config"]);
+        }
+        if ("issues" in $$parsedSource) {
+            $$parsedSource["issues"] = $$createField2_0($$parsedSource["issues"]);
+        }
+        return new SolutionConfigSaveResult(/** @type {Partial<SolutionConfigSaveResult>} */($$parsedSource));
+    }
+}
+
 export class SolutionModule {
     /**
      * Creates a new SolutionModule instance.
@@ -615,6 +3943,59 @@ export class SolutionModule {
     }
 }
 
+export class SolutionRequest {
+    /**
+     * Creates a new SolutionRequest instance.
+     * @param {Partial<SolutionRequest>} [$$source = {}] - The source object to create the SolutionRequest.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("description" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["description"] = "";
+        }
+        if (!("organization" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["organization"] = "";
+        }
+        if (!("modules" in $$source)) {
+            /**
+             * @member
+             * @type {SolutionModule[]}
+             */
+            this["modules"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new SolutionRequest instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {SolutionRequest}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType73;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("modules" in $$parsedSource) {
+            $$parsedSource["modules"] = $$createField3_0($$parsedSource["modules"]);
+        }
+        return new SolutionRequest(/** @type {Partial<SolutionRequest>} */($$parsedSource));
+    }
+}
+
 export class SystemInfo {
     /**
      * Creates a new SystemInfo instance.
@@ -657,17 +4038,115 @@ export class SystemInfo {
     }
 }
 
+export class UpdateEnvironmentRequest {
+    /**
+     * Creates a new UpdateEnvironmentRequest instance.
+     * @param {Partial<UpdateEnvironmentRequest>} [$$source = {}] - The source object to create the UpdateEnvironmentRequest.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("namespace" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["namespace"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new UpdateEnvironmentRequest instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {UpdateEnvironmentRequest}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new UpdateEnvironmentRequest(/** @type {Partial<UpdateEnvironmentRequest>} */($$parsedSource));
+    }
+}
+
 // Private type creation functions
-const $$createType0 = EnvironmentModule.createFrom;
-const $$createType1 = $Create.Array($$createType0);
-const $$createType2 = $Create.Map($Create.Any, $Create.Any);
-const $$createType3 = $Create.Array($Create.Any);
-const $$createType4 = ModuleDependency.createFrom;
-const $$createType5 = $Create.Array($$createType4);
-const $$createType6 = ModuleAttributes.createFrom;
-const $$createType7 = ModuleComponent.createFrom;
+const $$createType0 = Environment.createFrom;
+const $$createType1 = FieldError.createFrom;
+const $$createType2 = $Create.Array($$createType1);
+const $$createType3 = ModuleDiagnostics.createFrom;
+const $$createType4 = $Create.Array($$createType3);
+const $$createType5 = ResourceQuantities.createFrom;
+const $$createType6 = $Create.Map($Create.Any, $Create.Any);
+const $$createType7 = ConfigChange.createFrom;
 const $$createType8 = $Create.Array($$createType7);
-const $$createType9 = SolutionModule.createFrom;
-const $$createType10 = $Create.Array($$createType9);
-const $$createType11 = Environment.createFrom;
-const $$createType12 = $Create.Array($$createType11);
+const $$createType9 = EnvironmentConfig.createFrom;
+const $$createType10 = ConfigIssue.createFrom;
+const $$createType11 = $Create.Array($$createType10);
+const $$createType12 = DependencyNode.createFrom;
+const $$createType13 = $Create.Array($$createType12);
+const $$createType14 = DependencyEdge.createFrom;
+const $$createType15 = $Create.Array($$createType14);
+const $$createType16 = $Create.Array($Create.Any);
+const $$createType17 = $Create.Array($$createType16);
+const $$createType18 = ModuleVersionChange.createFrom;
+const $$createType19 = $Create.Array($$createType18);
+const $$createType20 = EnvironmentModule.createFrom;
+const $$createType21 = $Create.Array($$createType20);
+const $$createType22 = EffectiveValue.createFrom;
+const $$createType23 = $Create.Map($Create.Any, $$createType22);
+const $$createType24 = $Create.Map($Create.Any, $$createType23);
+const $$createType25 = ResourcePlan.createFrom;
+const $$createType26 = $Create.Array($Create.Any);
+const $$createType27 = EnvironmentSummary.createFrom;
+const $$createType28 = $Create.Array($$createType27);
+const $$createType29 = ModuleComparison.createFrom;
+const $$createType30 = $Create.Array($$createType29);
+const $$createType31 = ConfigComparison.createFrom;
+const $$createType32 = $Create.Array($$createType31);
+const $$createType33 = $Create.Map($Create.Any, $Create.Any);
+const $$createType34 = $Create.Map($Create.Any, $$createType6);
+const $$createType35 = ModuleManifests.createFrom;
+const $$createType36 = $Create.Array($$createType35);
+const $$createType37 = ModuleEnvironmentState.createFrom;
+const $$createType38 = $Create.Array($$createType37);
+const $$createType39 = CatalogDiagnostic.createFrom;
+const $$createType40 = $Create.Array($$createType39);
+const $$createType41 = $Create.Array($Create.Any);
+const $$createType42 = FacetValue.createFrom;
+const $$createType43 = $Create.Array($$createType42);
+const $$createType44 = $Create.Array($Create.Any);
+const $$createType45 = RenderedManifest.createFrom;
+const $$createType46 = $Create.Array($$createType45);
+const $$createType47 = ModuleSearchResult.createFrom;
+const $$createType48 = $Create.Array($$createType47);
+const $$createType49 = ModuleFacets.createFrom;
+const $$createType50 = LookupError.createFrom;
+const $$createType51 = $Create.Nullable($$createType50);
+const $$createType52 = ModuleDependency.createFrom;
+const $$createType53 = $Create.Array($$createType52);
+const $$createType54 = ModuleAttributes.createFrom;
+const $$createType55 = ModuleComponent.createFrom;
+const $$createType56 = $Create.Array($$createType55);
+const $$createType57 = ModuleChart.createFrom;
+const $$createType58 = $Create.Nullable($$createType57);
+const $$createType59 = ModuleResponse.createFrom;
+const $$createType60 = SearchHighlight.createFrom;
+const $$createType61 = $Create.Array($$createType60);
+const $$createType62 = OperationStep.createFrom;
+const $$createType63 = $Create.Array($$createType62);
+const $$createType64 = ModulePromotion.createFrom;
+const $$createType65 = $Create.Array($$createType64);
+const $$createType66 = ComponentResourcePlan.createFrom;
+const $$createType67 = $Create.Array($$createType66);
+const $$createType68 = ResourceIssue.createFrom;
+const $$createType69 = $Create.Array($$createType68);
+const $$createType70 = ComponentResources.createFrom;
+const $$createType71 = $Create.Map($Create.Any, $$createType70);
+const $$createType72 = SolutionModule.createFrom;
+const $$createType73 = $Create.Array($$createType72);
+const $$createType74 = $Create.Array($$createType0);
+const $$createType75 = SolutionConfig.createFrom;
@@ -2,6 +2,12 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+/**
+ * ModuleService serves the module catalog. The catalog doesn't change after NewModuleService,
+ * so it's safe to read concurrently; responses are copies so callers can't change it.
+ * @module
+ */
+
 // eslint-disable-next-line @typescript-eslint/ban-ts-comment
 // @ts-ignore: Unused imports
 import {Call as $Call, Create as $Create} from "@wailsio/runtime";
@@ -10,6 +16,19 @@ import {Call as $Call, Create as $Create} from "@wailsio/runtime";
 // @ts-ignore: Unused imports
 import * as $models from "./models.js";
 
+/**
+ * GetDependencyGraph returns the dependency graph of the whole catalog
+ * @returns {Promise<$models.DependencyGraph> & { cancel(): void }}
+ */
+export function GetDependencyGraph() {
+    let $resultPromise = /** @type {any} */($Call.ByID(2449907310));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType0($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
 /**
  * @param {string} id
  * @returns {Promise<$models.ModuleResponse | null> & { cancel(): void }}
@@ -17,7 +36,7 @@ import * as $models from "./models.js";
 export function GetModule(id) {
     let $resultPromise = /** @type {any} */($Call.ByID(1305056591, id));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType1($result);
+        return $$createType2($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
@@ -26,11 +45,15 @@ export function GetModule(id) {
 /**
  * GetModuleReadme fetches the README content from GitHub if available
  * @param {string} id
- * @returns {Promise<string> & { cancel(): void }}
+ * @returns {Promise<$models.ModuleReadme> & { cancel(): void }}
  */
 export function GetModuleReadme(id) {
     let $resultPromise = /** @type {any} */($Call.ByID(2350751181, id));
-    return $resultPromise;
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType3($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
 }
 
 /**
@@ -39,26 +62,109 @@ export function GetModuleReadme(id) {
 export function GetModules() {
     let $resultPromise = /** @type {any} */($Call.ByID(2958421364));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType2($result);
+        return $$createType4($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
 }
 
 /**
+ * GetReverseDependencies returns the module and every module that depends on it, directly
+ * or indirectly, i.e. everything affected by a change to it. Edges keep pointing from
+ * dependent to dependency.
+ * @param {string} id
+ * @returns {Promise<$models.DependencyGraph> & { cancel(): void }}
+ */
+export function GetReverseDependencies(id) {
+    let $resultPromise = /** @type {any} */($Call.ByID(844453058, id));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType0($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetTransitiveDependencies returns the module and everything it depends on, directly or
+ * indirectly. Node depth is the shortest distance from the module.
+ * @param {string} id
+ * @returns {Promise<$models.DependencyGraph> & { cancel(): void }}
+ */
+export function GetTransitiveDependencies(id) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1150641581, id));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType0($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * QueryModules filters the catalog by free text and facets. The facet counts of each
+ * facet are computed with every other facet applied, so selecting a value doesn't hide
+ * the alternatives in the same facet.
+ * @param {$models.ModuleFilter} filter
+ * @returns {Promise<$models.ModuleQueryResult> & { cancel(): void }}
+ */
+export function QueryModules(filter) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1055961104, filter));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType5($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * SearchModuleMatches searches name, description, tags, component names, maintainer and
+ * README text, tolerating typos. Results are ranked by relevance and include highlighted
+ * snippets of the fields that matched.
+ * @param {string} query
+ * @returns {Promise<$models.ModuleSearchResult[]> & { cancel(): void }}
+ */
+export function SearchModuleMatches(query) {
+    let $resultPromise = /** @type {any} */($Call.ByID(265304286, query));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType7($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * SearchModules returns the modules matching query, best match first
  * @param {string} query
  * @returns {Promise<$models.ModuleResponse[]> & { cancel(): void }}
  */
 export function SearchModules(query) {
     let $resultPromise = /** @type {any} */($Call.ByID(856364626, query));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType2($result);
+        return $$createType4($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * ValidateCatalog checks the module catalog for broken references and malformed entries
+ * @returns {Promise<$models.CatalogValidation> & { cancel(): void }}
+ */
+export function ValidateCatalog() {
+    let $resultPromise = /** @type {any} */($Call.ByID(3306327554));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType8($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
 }
 
 // Private type creation functions
-const $$createType0 = $models.ModuleResponse.createFrom;
-const $$createType1 = $Create.Nullable($$createType0);
-const $$createType2 = $Create.Array($$createType0);
+const $$createType0 = $models.DependencyGraph.createFrom;
+const $$createType1 = $models.ModuleResponse.createFrom;
+const $$createType2 = $Create.Nullable($$createType1);
+const $$createType3 = $models.ModuleReadme.createFrom;
+const $$createType4 = $Create.Array($$createType1);
+const $$createType5 = $models.ModuleQueryResult.createFrom;
+const $$createType6 = $models.ModuleSearchResult.createFrom;
+const $$createType7 = $Create.Array($$createType6);
+const $$createType8 = $models.CatalogValidation.createFrom;
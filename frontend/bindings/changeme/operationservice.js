@@ -0,0 +1,61 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+/**
+ * OperationService runs operations in the background and keeps track of them, including
+ * those of earlier runs of the app. Every change is emitted as an operationEvent.
+ * @module
+ */
+
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import {Call as $Call, Create as $Create} from "@wailsio/runtime";
+
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as $models from "./models.js";
+
+/**
+ * CancelOperation asks a running operation to stop. It ends as cancelled once the step
+ * it's in gives up.
+ * @param {string} id
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function CancelOperation(id) {
+    let $resultPromise = /** @type {any} */($Call.ByID(830586451, id));
+    return $resultPromise;
+}
+
+/**
+ * GetOperation returns an operation of this or an earlier run of the app
+ * @param {string} id
+ * @returns {Promise<$models.Operation> & { cancel(): void }}
+ */
+export function GetOperation(id) {
+    let $resultPromise = /** @type {any} */($Call.ByID(324992785, id));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType0($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * ListOperations returns the operations of a solution, or of all solutions if solutionId
+ * is empty, newest first
+ * @param {string} solutionId
+ * @returns {Promise<$models.Operation[]> & { cancel(): void }}
+ */
+export function ListOperations(solutionId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(137440170, solutionId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType1($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+// Private type creation functions
+const $$createType0 = $models.Operation.createFrom;
+const $$createType1 = $Create.Array($$createType0);
@@ -13,13 +13,229 @@ import * as $models from "./models.js";
 /**
  * @param {string} solutionId
  * @param {$models.AddEnvironmentRequest} req
- * @returns {Promise<void> & { cancel(): void }}
+ * @returns {Promise<$models.AddEnvironmentResult> & { cancel(): void }}
  */
 export function AddEnvironment(solutionId, req) {
     let $resultPromise = /** @type {any} */($Call.ByID(255261038, solutionId, req));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType0($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * CloneSolution creates a solution with the same description, organization, modules,
+ * configuration and secrets as an existing one. Environments aren't cloned since their
+ * namespaces have to be unique.
+ * @param {string} id
+ * @param {string} name
+ * @returns {Promise<$models.Solution> & { cancel(): void }}
+ */
+export function CloneSolution(id, name) {
+    let $resultPromise = /** @type {any} */($Call.ByID(793976064, id, name));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType1($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * CompareEnvironments builds a matrix of module versions, statuses and configuration across
+ * all environments of a solution. Solution.Modules and the solution's configuration are
+ * the baseline, anything that deviates from it is reported as drift.
+ * @param {string} solutionId
+ * @returns {Promise<$models.EnvironmentComparison> & { cancel(): void }}
+ */
+export function CompareEnvironments(solutionId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(27989543, solutionId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType2($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * CreateSolution adds a solution. Its ID is derived from the name and made unique, also
+ * against data left behind by solutions that are gone, so a new solution never picks up
+ * their configuration, secrets or history.
+ * @param {$models.SolutionRequest} req
+ * @returns {Promise<$models.Solution> & { cancel(): void }}
+ */
+export function CreateSolution(req) {
+    let $resultPromise = /** @type {any} */($Call.ByID(764089741, req));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType1($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * DeleteEnvironment tears down everything deployed for an environment, including its
+ * namespace, and removes it along with its configuration and history. It runs in the
+ * background, the returned operation ID tracks it.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @returns {Promise<string> & { cancel(): void }}
+ */
+export function DeleteEnvironment(solutionId, environmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(723022658, solutionId, environmentId));
+    return $resultPromise;
+}
+
+/**
+ * DeleteSecret removes a secret from the vault of a solution
+ * @param {string} solutionId
+ * @param {string} name
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function DeleteSecret(solutionId, name) {
+    let $resultPromise = /** @type {any} */($Call.ByID(180681513, solutionId, name));
     return $resultPromise;
 }
 
+/**
+ * DeleteSolution removes a solution along with its stored configuration, history and
+ * secrets. Solutions with environments that run, are in error or have an operation in
+ * progress can't be deleted, stop or delete those environments first.
+ * @param {string} id
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function DeleteSolution(id) {
+    let $resultPromise = /** @type {any} */($Call.ByID(4203922406, id));
+    return $resultPromise;
+}
+
+/**
+ * DiffConfigRevisions compares two revisions of an environment's configuration. Revision 0
+ * stands for the current configuration.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @param {number} fromRevision
+ * @param {number} toRevision
+ * @returns {Promise<$models.ConfigDiff> & { cancel(): void }}
+ */
+export function DiffConfigRevisions(solutionId, environmentId, fromRevision, toRevision) {
+    let $resultPromise = /** @type {any} */($Call.ByID(4233476523, solutionId, environmentId, fromRevision, toRevision));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType3($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * DiffEnvironmentConfigs compares the current configuration of two environments of a solution
+ * @param {string} solutionId
+ * @param {string} fromEnvironmentId
+ * @param {string} toEnvironmentId
+ * @returns {Promise<$models.ConfigDiff> & { cancel(): void }}
+ */
+export function DiffEnvironmentConfigs(solutionId, fromEnvironmentId, toEnvironmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1845169425, solutionId, fromEnvironmentId, toEnvironmentId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType3($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * ExportDeploymentHistory returns the deployment history as a JSON document, oldest first,
+ * for archiving or audits. An empty environmentId exports the whole solution.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @returns {Promise<string> & { cancel(): void }}
+ */
+export function ExportDeploymentHistory(solutionId, environmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(4115029145, solutionId, environmentId));
+    return $resultPromise;
+}
+
+/**
+ * GetConfigHistory returns the revisions of an environment's configuration, newest first
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @returns {Promise<$models.ConfigRevision[]> & { cancel(): void }}
+ */
+export function GetConfigHistory(solutionId, environmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3670356608, solutionId, environmentId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType5($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetConfigRevision returns a single revision of an environment's configuration including its content
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @param {number} revision
+ * @returns {Promise<$models.ConfigRevision> & { cancel(): void }}
+ */
+export function GetConfigRevision(solutionId, environmentId, revision) {
+    let $resultPromise = /** @type {any} */($Call.ByID(2274900653, solutionId, environmentId, revision));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType4($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetDeploymentHistory returns the installs, upgrades, configuration changes, promotions
+ * and lifecycle changes of a solution's environments, newest first. An empty environmentId
+ * returns the history of the whole solution.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @returns {Promise<$models.DeploymentEvent[]> & { cancel(): void }}
+ */
+export function GetDeploymentHistory(solutionId, environmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3434702143, solutionId, environmentId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType7($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetEffectiveConfig returns the configuration an environment is deployed with: module
+ * defaults, overridden by the solution's values, overridden by the environment's own
+ * values, along with the layer every value comes from
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @returns {Promise<$models.EffectiveConfig> & { cancel(): void }}
+ */
+export function GetEffectiveConfig(solutionId, environmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3866267013, solutionId, environmentId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType8($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetEnvironmentConfig returns the stored configuration of an environment, or the
+ * default configuration if it was never saved
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @returns {Promise<$models.EnvironmentConfig> & { cancel(): void }}
+ */
+export function GetEnvironmentConfig(solutionId, environmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(514521513, solutionId, environmentId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType9($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
 /**
  * @param {string} solutionId
  * @returns {Promise<$models.Environment[]> & { cancel(): void }}
@@ -27,7 +243,48 @@ export function AddEnvironment(solutionId, req) {
 export function GetEnvironments(solutionId) {
     let $resultPromise = /** @type {any} */($Call.ByID(2528114680, solutionId));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType1($result);
+        return $$createType11($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetPromotionHistory returns the promotions applied to the environments of a solution, newest first
+ * @param {string} solutionId
+ * @returns {Promise<$models.PromotionRecord[]> & { cancel(): void }}
+ */
+export function GetPromotionHistory(solutionId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1762669481, solutionId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType13($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetResourceProfiles returns the resource profiles environments can choose from
+ * @returns {Promise<$models.ResourceProfile[]> & { cancel(): void }}
+ */
+export function GetResourceProfiles() {
+    let $resultPromise = /** @type {any} */($Call.ByID(973066840));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType15($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetSecrets lists the secrets in the vault of a solution
+ * @param {string} solutionId
+ * @returns {Promise<$models.SecretInfo[]> & { cancel(): void }}
+ */
+export function GetSecrets(solutionId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(712888623, solutionId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType17($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
@@ -40,7 +297,21 @@ export function GetEnvironments(solutionId) {
 export function GetSolution(id) {
     let $resultPromise = /** @type {any} */($Call.ByID(4002103797, id));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType3($result);
+        return $$createType18($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetSolutionConfig returns the values shared by all environments of a solution
+ * @param {string} solutionId
+ * @returns {Promise<$models.SolutionConfig> & { cancel(): void }}
+ */
+export function GetSolutionConfig(solutionId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(684542423, solutionId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType19($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
@@ -52,19 +323,20 @@ export function GetSolution(id) {
 export function GetSolutions() {
     let $resultPromise = /** @type {any} */($Call.ByID(188229106));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType4($result);
+        return $$createType20($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
 }
 
 /**
- * InstallModule installs a module in a development environment
+ * InstallModule installs a module in a development environment. The module is deployed in
+ * the background, the returned operation ID tracks it, see OperationService.
  * @param {string} solutionId
  * @param {string} environmentId
  * @param {string} moduleId
  * @param {string} version
- * @returns {Promise<void> & { cancel(): void }}
+ * @returns {Promise<string> & { cancel(): void }}
  */
 export function InstallModule(solutionId, environmentId, moduleId, version) {
     let $resultPromise = /** @type {any} */($Call.ByID(3769213321, solutionId, environmentId, moduleId, version));
@@ -81,9 +353,282 @@ export function IsDevelopmentEnvironment(env) {
     return $resultPromise;
 }
 
+/**
+ * PlanPromotion shows what promoting modules from one environment to another would change
+ * without applying anything. With no moduleIds all modules of the source are promoted.
+ * @param {string} solutionId
+ * @param {string} fromEnvironmentId
+ * @param {string} toEnvironmentId
+ * @param {string[]} moduleIds
+ * @returns {Promise<$models.PromotionPlan> & { cancel(): void }}
+ */
+export function PlanPromotion(solutionId, fromEnvironmentId, toEnvironmentId, moduleIds) {
+    let $resultPromise = /** @type {any} */($Call.ByID(4120169254, solutionId, fromEnvironmentId, toEnvironmentId, moduleIds));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType21($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * PlanRollback shows what rolling an environment back to a snapshot in its deployment
+ * history would change without applying anything, see GetDeploymentHistory
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @param {string} snapshotId
+ * @returns {Promise<$models.RollbackPlan> & { cancel(): void }}
+ */
+export function PlanRollback(solutionId, environmentId, snapshotId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(2138360361, solutionId, environmentId, snapshotId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType22($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * PreviewManifests renders the charts of an environment's modules without deploying
+ * anything, showing what would be applied. Secrets are masked. With a moduleId only that
+ * module is rendered; a module that isn't installed yet is rendered as it would be
+ * installed. Modules without a chart are left out.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @param {string} moduleId
+ * @returns {Promise<$models.ManifestPreview> & { cancel(): void }}
+ */
+export function PreviewManifests(solutionId, environmentId, moduleId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3628258572, solutionId, environmentId, moduleId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType23($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * PromoteEnvironment moves the versions and configuration of modules from one environment
+ * to another, see PlanPromotion, and deploys them to the target. Nothing is changed if the
+ * plan violates a policy of the target environment. The promotion is recorded in the
+ * solution's promotion history and the target's configuration history. The deployment
+ * runs in the background, the returned operation ID tracks it.
+ * @param {string} solutionId
+ * @param {string} fromEnvironmentId
+ * @param {string} toEnvironmentId
+ * @param {string[]} moduleIds
+ * @returns {Promise<string> & { cancel(): void }}
+ */
+export function PromoteEnvironment(solutionId, fromEnvironmentId, toEnvironmentId, moduleIds) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1803573283, solutionId, fromEnvironmentId, toEnvironmentId, moduleIds));
+    return $resultPromise;
+}
+
+/**
+ * RefreshEnvironmentStatus updates the status of an environment and its modules from what
+ * actually runs and returns the environment. A module is running when all its components
+ * run, stopped when none does and in error if some don't or any crashed. Environments
+ * with a change in progress, and deployers that can't tell what runs, leave it as it is.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @returns {Promise<$models.Environment> & { cancel(): void }}
+ */
+export function RefreshEnvironmentStatus(solutionId, environmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(2631584720, solutionId, environmentId));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType10($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * RollbackEnvironment restores the module versions and configuration an environment had
+ * at a snapshot in its deployment history, see PlanRollback, and deploys them. Nothing is
+ * changed if the plan is blocked. The rollback is applied like any other deployment, so
+ * it's recorded in the configuration and deployment history and can itself be rolled
+ * back. The deployment runs in the background, the returned operation ID tracks it.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @param {string} snapshotId
+ * @returns {Promise<string> & { cancel(): void }}
+ */
+export function RollbackEnvironment(solutionId, environmentId, snapshotId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(2969630503, solutionId, environmentId, snapshotId));
+    return $resultPromise;
+}
+
+/**
+ * RollbackEnvironmentConfig restores a revision of an environment's configuration and
+ * deploys it again. The restored configuration is recorded as a new revision, so the
+ * rollback itself can be undone. It's validated like any other save since the modules
+ * of the environment may have changed since. The deployment runs in the background, the
+ * operation ID in the result tracks it.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @param {number} revision
+ * @returns {Promise<$models.ConfigSaveResult> & { cancel(): void }}
+ */
+export function RollbackEnvironmentConfig(solutionId, environmentId, revision) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3541359525, solutionId, environmentId, revision));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType24($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * SaveEnvironmentConfig validates and stores the configuration of an environment and
+ * deploys it. When the configuration is invalid nothing is stored and the issues are
+ * returned in the result. Comments and key order of the stored document are kept, see
+ * mergeYAML. Every save is recorded as a revision with the given message, see
+ * GetConfigHistory. The deployment runs in the background, the operation ID in the
+ * result tracks it.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @param {string} content
+ * @param {string} message
+ * @returns {Promise<$models.ConfigSaveResult> & { cancel(): void }}
+ */
+export function SaveEnvironmentConfig(solutionId, environmentId, content, message) {
+    let $resultPromise = /** @type {any} */($Call.ByID(2013175510, solutionId, environmentId, content, message));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType24($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * SaveSolutionConfig validates and stores the values shared by all environments of a
+ * solution. The change is rejected if it leaves any environment with invalid values.
+ * @param {string} solutionId
+ * @param {string} content
+ * @returns {Promise<$models.SolutionConfigSaveResult> & { cancel(): void }}
+ */
+export function SaveSolutionConfig(solutionId, content) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1387420342, solutionId, content));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType25($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * SetSecret encrypts a value and stores it in the vault of a solution, replacing any
+ * existing secret with the same name. Configuration refers to it with secretRef.vault.
+ * @param {string} solutionId
+ * @param {string} name
+ * @param {string} value
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function SetSecret(solutionId, name, value) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1699392802, solutionId, name, value));
+    return $resultPromise;
+}
+
+/**
+ * StartEnvironment scales the components of a stopped environment back to the replicas
+ * its resource profile and overrides give them. It runs in the background, the returned
+ * operation ID tracks it.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @returns {Promise<string> & { cancel(): void }}
+ */
+export function StartEnvironment(solutionId, environmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(29881479, solutionId, environmentId));
+    return $resultPromise;
+}
+
+/**
+ * StopEnvironment scales all components of an environment to zero. The configuration and
+ * data stay, StartEnvironment brings it back. It runs in the background, the returned
+ * operation ID tracks it.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @returns {Promise<string> & { cancel(): void }}
+ */
+export function StopEnvironment(solutionId, environmentId) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1174884135, solutionId, environmentId));
+    return $resultPromise;
+}
+
+/**
+ * SyncComponents re-applies the desired state of the selected components of an environment
+ * and restarts them, leaving the rest of the environment alone. Every component is a step
+ * of the returned operation, so a component that fails to sync doesn't stop the others
+ * and the operation's steps show the result per component. Afterwards the status of the
+ * affected modules and the environment reflects the outcome, see reconcileStatus.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @param {string[]} componentIds
+ * @returns {Promise<string> & { cancel(): void }}
+ */
+export function SyncComponents(solutionId, environmentId, componentIds) {
+    let $resultPromise = /** @type {any} */($Call.ByID(2305001765, solutionId, environmentId, componentIds));
+    return $resultPromise;
+}
+
+/**
+ * UpdateEnvironment renames an environment or moves it to another namespace. The ID stays
+ * the same. The namespace can only be changed while the environment is stopped, otherwise
+ * whatever runs in the old namespace would be left behind.
+ * @param {string} solutionId
+ * @param {string} environmentId
+ * @param {$models.UpdateEnvironmentRequest} req
+ * @returns {Promise<$models.Environment> & { cancel(): void }}
+ */
+export function UpdateEnvironment(solutionId, environmentId, req) {
+    let $resultPromise = /** @type {any} */($Call.ByID(2914647332, solutionId, environmentId, req));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType10($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * UpdateSolution renames a solution or changes its description, organization and declared
+ * modules. The ID stays the same. Modules are left as they are when req.Modules is nil.
+ * @param {string} id
+ * @param {$models.SolutionRequest} req
+ * @returns {Promise<$models.Solution> & { cancel(): void }}
+ */
+export function UpdateSolution(id, req) {
+    let $resultPromise = /** @type {any} */($Call.ByID(583718388, id, req));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType1($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
 // Private type creation functions
-const $$createType0 = $models.Environment.createFrom;
-const $$createType1 = $Create.Array($$createType0);
-const $$createType2 = $models.Solution.createFrom;
-const $$createType3 = $Create.Nullable($$createType2);
-const $$createType4 = $Create.Array($$createType2);
+const $$createType0 = $models.AddEnvironmentResult.createFrom;
+const $$createType1 = $models.Solution.createFrom;
+const $$createType2 = $models.EnvironmentComparison.createFrom;
+const $$createType3 = $models.ConfigDiff.createFrom;
+const $$createType4 = $models.ConfigRevision.createFrom;
+const $$createType5 = $Create.Array($$createType4);
+const $$createType6 = $models.DeploymentEvent.createFrom;
+const $$createType7 = $Create.Array($$createType6);
+const $$createType8 = $models.EffectiveConfig.createFrom;
+const $$createType9 = $models.EnvironmentConfig.createFrom;
+const $$createType10 = $models.Environment.createFrom;
+const $$createType11 = $Create.Array($$createType10);
+const $$createType12 = $models.PromotionRecord.createFrom;
+const $$createType13 = $Create.Array($$createType12);
+const $$createType14 = $models.ResourceProfile.createFrom;
+const $$createType15 = $Create.Array($$createType14);
+const $$createType16 = $models.SecretInfo.createFrom;
+const $$createType17 = $Create.Array($$createType16);
+const $$createType18 = $Create.Nullable($$createType1);
+const $$createType19 = $models.SolutionConfig.createFrom;
+const $$createType20 = $Create.Array($$createType1);
+const $$createType21 = $models.PromotionPlan.createFrom;
+const $$createType22 = $models.RollbackPlan.createFrom;
+const $$createType23 = $models.ManifestPreview.createFrom;
+const $$createType24 = $models.ConfigSaveResult.createFrom;
+const $$createType25 = $models.SolutionConfigSaveResult.createFrom;
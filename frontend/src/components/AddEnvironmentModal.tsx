@@ -16,18 +16,30 @@ export function AddEnvironmentModal({
   const [name, setName] = useState("");
   const [namespace, setNamespace] = useState("");
   const [error, setError] = useState<string | null>(null);
+  const [fieldErrors, setFieldErrors] = useState<Record<string, string>>({});
   const [loading, setLoading] = useState(false);
 
   const handleSubmit = async (e: React.FormEvent) => {
     e.preventDefault();
     setError(null);
+    setFieldErrors({});
     setLoading(true);
 
     try {
-      await SolutionService.AddEnvironment(solutionId, {
+      const result = await SolutionService.AddEnvironment(solutionId, {
         name,
         namespace,
       });
+      if (!result.added) {
+        const errors: Record<string, string> = {};
+        for (const fieldError of result.errors ?? []) {
+          if (!errors[fieldError.field]) {
+            errors[fieldError.field] = fieldError.message;
+          }
+        }
+        setFieldErrors(errors);
+        return;
+      }
       onEnvironmentAdded();
       onClose();
     } catch (err) {
@@ -75,6 +87,9 @@ export function AddEnvironmentModal({
               placeholder="e.g., Development, Staging, Production"
               required
             />
+            {fieldErrors.name && (
+              <p className="mt-1 text-sm text-red-600">{fieldErrors.name}</p>
+            )}
           </div>
 
           <div>
@@ -93,6 +108,16 @@ export function AddEnvironmentModal({
               placeholder="e.g., customer-dev, customer-prod"
               required
             />
+            {fieldErrors.namespace ? (
+              <p className="mt-1 text-sm text-red-600">
+                {fieldErrors.namespace}
+              </p>
+            ) : (
+              <p className="mt-1 text-sm text-gray-500">
+                Lowercase letters, digits and dashes, unique across all
+                solutions
+              </p>
+            )}
           </div>
 
           <div className="flex justify-end gap-3 mt-6">
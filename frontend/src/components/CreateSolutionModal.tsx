@@ -0,0 +1,128 @@
+import { useState } from "react";
+import { Solution, SolutionService } from "../../bindings/changeme";
+import { Button } from "@stacc/prism-ui";
+
+interface CreateSolutionModalProps {
+  onClose: () => void;
+  onSolutionCreated: (solution: Solution) => void;
+}
+
+export function CreateSolutionModal({
+  onClose,
+  onSolutionCreated,
+}: CreateSolutionModalProps) {
+  const [name, setName] = useState("");
+  const [organization, setOrganization] = useState("");
+  const [description, setDescription] = useState("");
+  const [error, setError] = useState<string | null>(null);
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError(null);
+    setLoading(true);
+
+    try {
+      const solution = await SolutionService.CreateSolution({
+        name,
+        organization,
+        description,
+        modules: [],
+      });
+      onSolutionCreated(solution);
+      onClose();
+    } catch (err) {
+      setError(
+        err instanceof Error ? err.message : "Failed to create solution"
+      );
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div className="fixed inset-0 bg-black/50 flex items-center justify-center">
+      <div className="bg-white rounded-lg p-6 w-full max-w-md">
+        <div className="flex justify-between items-center mb-6">
+          <h2 className="text-xl font-semibold text-gray-800">New Solution</h2>
+          <button
+            onClick={onClose}
+            className="text-gray-500 hover:text-gray-700"
+          >
+            ✕
+          </button>
+        </div>
+
+        {error && (
+          <div className="mb-4 p-3 bg-red-50 text-red-700 rounded">{error}</div>
+        )}
+
+        <form onSubmit={handleSubmit} className="space-y-4">
+          <div>
+            <label
+              htmlFor="name"
+              className="block text-sm font-medium text-gray-700 mb-1"
+            >
+              Name
+            </label>
+            <input
+              type="text"
+              id="name"
+              value={name}
+              onChange={(e) => setName(e.target.value)}
+              className="w-full p-2 border rounded focus:ring-2 focus:ring-blue-500 outline-none"
+              placeholder="e.g., Customer Onboarding"
+              required
+            />
+          </div>
+
+          <div>
+            <label
+              htmlFor="organization"
+              className="block text-sm font-medium text-gray-700 mb-1"
+            >
+              Organization
+            </label>
+            <input
+              type="text"
+              id="organization"
+              value={organization}
+              onChange={(e) => setOrganization(e.target.value)}
+              className="w-full p-2 border rounded focus:ring-2 focus:ring-blue-500 outline-none"
+            />
+          </div>
+
+          <div>
+            <label
+              htmlFor="description"
+              className="block text-sm font-medium text-gray-700 mb-1"
+            >
+              Description
+            </label>
+            <textarea
+              id="description"
+              value={description}
+              onChange={(e) => setDescription(e.target.value)}
+              className="w-full p-2 border rounded focus:ring-2 focus:ring-blue-500 outline-none"
+              rows={3}
+            />
+          </div>
+
+          <div className="flex justify-end gap-3 mt-6">
+            <Button
+              type="button"
+              onClick={onClose}
+              label="Cancel"
+              variant="outline"
+            />
+            <Button
+              type="submit"
+              label={loading ? "Creating..." : "Create Solution"}
+              disabled={loading}
+            />
+          </div>
+        </form>
+      </div>
+    </div>
+  );
+}
@@ -1,5 +1,9 @@
 import { useState, useEffect } from "react";
-import { Environment, ModuleService } from "../../bindings/changeme";
+import {
+  Environment,
+  ModuleService,
+  SolutionService,
+} from "../../bindings/changeme";
 import { Button, TextField, UNSTABLE_Select } from "@stacc/prism-ui";
 import CodeMirror from "@uiw/react-codemirror";
 import { yaml } from "@codemirror/lang-yaml";
@@ -174,11 +178,13 @@ function AddValueModal({ onClose, onAdd }: AddValueModalProps) {
 
 export function EnvironmentConfig({
   environment,
+  solutionId,
   onSave,
 }: EnvironmentConfigProps) {
   const [config, setConfig] = useState<ConfigValues>(defaultConfig);
   const [isEditing, setIsEditing] = useState(false);
   const [isSaving, setIsSaving] = useState(false);
+  const [saveError, setSaveError] = useState<string | null>(null);
   const [viewMode, setViewMode] = useState<ViewMode>("form");
   const [editorContent, setEditorContent] = useState("");
   const [showAddModuleModal, setShowAddModuleModal] = useState(false);
@@ -186,6 +192,49 @@ export function EnvironmentConfig({
   const [selectedModuleName, setSelectedModuleName] = useState<string | null>(
     null
   );
+  const [resourceProfiles, setResourceProfiles] = useState<
+    Array<{ label: string; value: string }>
+  >([]);
+
+  useEffect(() => {
+    SolutionService.GetResourceProfiles().then((profiles) =>
+      setResourceProfiles(
+        profiles.map((profile) => ({
+          label: profile.label,
+          value: profile.name,
+        }))
+      )
+    );
+  }, []);
+
+  useEffect(() => {
+    SolutionService.GetEnvironmentConfig(solutionId, environment.id).then(
+      (saved) => {
+        setConfig({
+          resourceProfile: saved.resourceProfile || defaultConfig.resourceProfile,
+          domainSuffix: saved.global.domainSuffix ?? defaultConfig.domainSuffix,
+          moduleConfigs: Object.fromEntries(
+            Object.entries(saved.modules).map(([moduleId, values]) => [
+              moduleId,
+              {
+                enabled: true,
+                // Nested values such as secret references are edited as
+                // inline YAML, JSON being a subset of it
+                values: Object.fromEntries(
+                  Object.entries(values).map(([key, value]) => [
+                    key,
+                    typeof value === "object" && value !== null
+                      ? JSON.stringify(value)
+                      : String(value),
+                  ])
+                ),
+              },
+            ])
+          ),
+        });
+      }
+    );
+  }, [solutionId, environment.id]);
 
   // Convert form values to YAML format
   const getYamlConfig = () => {
@@ -218,9 +267,13 @@ ${Object.entries(moduleConfig.values)
       const configToSave =
         viewMode === "form" ? getYamlConfig() : editorContent;
       await onSave(configToSave);
+      setSaveError(null);
       setIsEditing(false);
     } catch (error) {
       console.error("Failed to save configuration:", error);
+      setSaveError(
+        error instanceof Error ? error.message : "Failed to save configuration"
+      );
     } finally {
       setIsSaving(false);
     }
@@ -301,6 +354,12 @@ ${Object.entries(moduleConfig.values)
         </div>
       </div>
 
+      {saveError && (
+        <div className="mb-6 rounded border border-red-200 bg-red-50 p-4 text-sm text-red-700 whitespace-pre-line">
+          {saveError}
+        </div>
+      )}
+
       {isEditing && (
         <div className="mb-6 flex gap-4 border-b">
           <button
@@ -344,20 +403,7 @@ ${Object.entries(moduleConfig.values)
                     resourceProfile: e.target.value,
                   }))
                 }
-                options={[
-                  {
-                    label: "Small",
-                    value: "small",
-                  },
-                  {
-                    label: "Medium",
-                    value: "medium",
-                  },
-                  {
-                    label: "Large",
-                    value: "large",
-                  },
-                ]}
+                options={resourceProfiles}
                 disabled={!isEditing}
               />
               <TextField
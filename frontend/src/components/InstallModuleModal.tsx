@@ -1,5 +1,6 @@
 import { useState } from "react";
 import { SolutionService } from "../../bindings/changeme";
+import { waitForOperation } from "../operations";
 
 interface InstallModuleModalProps {
   solutionId: string;
@@ -25,12 +26,13 @@ export function InstallModuleModal({
     setIsLoading(true);
 
     try {
-      await SolutionService.InstallModule(
+      const operationId = await SolutionService.InstallModule(
         solutionId,
         environmentId,
         moduleId,
         version
       );
+      await waitForOperation(operationId);
       onModuleInstalled();
       onClose();
     } catch (err) {
@@ -1,6 +1,11 @@
 import { useState, useEffect } from "react";
-import { SolutionService, Solution } from "../../bindings/changeme";
+import {
+  SolutionService,
+  Solution,
+  ModuleManifests,
+} from "../../bindings/changeme";
 import { Button, UNSTABLE_Select } from "@stacc/prism-ui";
+import { waitForOperation } from "../operations";
 
 interface InstallToEnvironmentModalProps {
   moduleId: string;
@@ -26,6 +31,8 @@ export function InstallToEnvironmentModal({
   const [error, setError] = useState<string | null>(null);
   const [isLoading, setIsLoading] = useState(false);
   const [isFetching, setIsFetching] = useState(true);
+  const [preview, setPreview] = useState<ModuleManifests[] | null>(null);
+  const [isPreviewing, setIsPreviewing] = useState(false);
 
   useEffect(() => {
     const fetchSolutions = async () => {
@@ -68,12 +75,13 @@ export function InstallToEnvironmentModal({
     setIsLoading(true);
 
     try {
-      await SolutionService.InstallModule(
+      const operationId = await SolutionService.InstallModule(
         selectedEnvironment.solutionId,
         selectedEnvironment.environmentId,
         moduleId,
         version
       );
+      await waitForOperation(operationId);
       onClose();
     } catch (err) {
       setError(err instanceof Error ? err.message : "Failed to install module");
@@ -82,6 +90,27 @@ export function InstallToEnvironmentModal({
     }
   };
 
+  const handlePreview = async () => {
+    if (!selectedEnvironment) return;
+
+    setError(null);
+    setIsPreviewing(true);
+    try {
+      const result = await SolutionService.PreviewManifests(
+        selectedEnvironment.solutionId,
+        selectedEnvironment.environmentId,
+        moduleId
+      );
+      setPreview(result.modules);
+    } catch (err) {
+      setError(
+        err instanceof Error ? err.message : "Failed to render manifests"
+      );
+    } finally {
+      setIsPreviewing(false);
+    }
+  };
+
   const selectOptions = developmentEnvironments.map((env) => ({
     label: `${env.solutionName} - ${env.environmentName}`,
     value: `${env.solutionId}:${env.environmentId}`,
@@ -95,6 +124,7 @@ export function InstallToEnvironmentModal({
         env.solutionId === solutionId && env.environmentId === environmentId
     );
     setSelectedEnvironment(env || null);
+    setPreview(null);
   };
 
   if (isFetching) {
@@ -111,7 +141,11 @@ export function InstallToEnvironmentModal({
 
   return (
     <div className="fixed inset-0 bg-black/50 z-50 flex items-center justify-center p-4">
-      <div className="bg-white rounded-lg p-6 w-full max-w-md">
+      <div
+        className={`bg-white rounded-lg p-6 w-full ${
+          preview ? "max-w-3xl" : "max-w-md"
+        }`}
+      >
         <div className="flex justify-between items-center mb-4">
           <h2 className="text-xl font-semibold">Install Module</h2>
           <button
@@ -143,6 +177,40 @@ export function InstallToEnvironmentModal({
               />
             </div>
 
+            {preview && (
+              <div className="mb-4 max-h-96 overflow-y-auto space-y-4">
+                {preview.length === 0 && (
+                  <div className="text-gray-600">
+                    This module has no chart, its components are deployed
+                    directly.
+                  </div>
+                )}
+                {preview.map((module) => (
+                  <div key={module.moduleId}>
+                    <div className="text-sm font-medium text-gray-700 mb-2">
+                      {module.chart || module.moduleId}
+                    </div>
+                    {module.error ? (
+                      <div className="p-2 bg-red-100 border border-red-400 text-red-700 rounded text-sm">
+                        {module.error}
+                      </div>
+                    ) : (
+                      module.manifests.map((manifest) => (
+                        <div key={manifest.template} className="mb-2">
+                          <div className="text-xs text-gray-500 mb-1">
+                            {manifest.template}
+                          </div>
+                          <pre className="bg-gray-50 border border-gray-200 rounded p-2 text-xs overflow-x-auto">
+                            {manifest.content}
+                          </pre>
+                        </div>
+                      ))
+                    )}
+                  </div>
+                ))}
+              </div>
+            )}
+
             {error && (
               <div className="mb-4 p-2 bg-red-100 border border-red-400 text-red-700 rounded">
                 {error}
@@ -157,6 +225,14 @@ export function InstallToEnvironmentModal({
               >
                 Cancel
               </button>
+              <button
+                type="button"
+                onClick={handlePreview}
+                disabled={isPreviewing || !selectedEnvironment}
+                className="px-4 py-2 text-gray-700 border border-gray-300 rounded-lg hover:bg-gray-50 disabled:opacity-50"
+              >
+                {isPreviewing ? "Rendering..." : "Preview manifests"}
+              </button>
               <Button
                 type="submit"
                 label={isLoading ? "Installing..." : "Install"}
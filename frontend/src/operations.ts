@@ -0,0 +1,48 @@
+import { Events } from "@wailsio/runtime";
+import { OperationService } from "../bindings/changeme";
+import type { Operation } from "../bindings/changeme/models";
+
+// Must match operationEvent in operations.go
+const operationEvent = "operation:updated";
+
+const isFinished = (operation: Operation) =>
+  ["succeeded", "failed", "cancelled"].includes(operation.status);
+
+/**
+ * Resolves once the operation has succeeded and rejects if it failed or was
+ * cancelled. onProgress is called with every update along the way.
+ */
+export function waitForOperation(
+  id: string,
+  onProgress?: (operation: Operation) => void
+): Promise<Operation> {
+  return new Promise((resolve, reject) => {
+    const settle = (operation: Operation) => {
+      onProgress?.(operation);
+      if (!isFinished(operation)) {
+        return;
+      }
+      unsubscribe();
+      if (operation.status === "succeeded") {
+        resolve(operation);
+      } else {
+        reject(new Error(operation.error || `Operation ${operation.status}`));
+      }
+    };
+
+    const unsubscribe = Events.On(operationEvent, (event) => {
+      const operation = (
+        Array.isArray(event.data) ? event.data[0] : event.data
+      ) as Operation;
+      if (operation?.id === id) {
+        settle(operation);
+      }
+    });
+
+    // The operation may have finished before we started listening
+    OperationService.GetOperation(id).then(settle, (err) => {
+      unsubscribe();
+      reject(err);
+    });
+  });
+}
@@ -1,5 +1,5 @@
 import { createFileRoute } from "@tanstack/react-router";
-import { ComponentType } from "../../../bindings/changeme";
+import { ComponentType, ErrorKind, LookupError } from "../../../bindings/changeme";
 import { useState } from "react";
 import { Terminal } from "../../components/Terminal";
 import { Button } from "@stacc/prism-ui";
@@ -50,12 +50,31 @@ const componentTypeIcons: Record<ComponentType, string> = {
   Setup: "🔧",
 };
 
+function readmeErrorMessage(error?: LookupError | null) {
+  switch (error?.kind) {
+    case ErrorKind.ErrorKindUnauthorized:
+      return "Not authorized to read this repository. Check your GitHub credentials.";
+    case ErrorKind.ErrorKindRateLimited:
+      return error.resetAt
+        ? `GitHub rate limit reached. Try again after ${new Date(error.resetAt).toLocaleTimeString()}.`
+        : "GitHub rate limit reached. Try again later.";
+    case ErrorKind.ErrorKindNetwork:
+      return "Could not reach GitHub. Check your network connection.";
+    case ErrorKind.ErrorKindInvalid:
+    case ErrorKind.ErrorKindUnknown:
+      return error.message;
+    default:
+      return "No README available";
+  }
+}
+
 export function ModuleDetail() {
   const { moduleId } = Route.useParams();
   const moduleQuery = useSuspenseQuery(queries.getModuleById(moduleId));
   const module = moduleQuery.data;
   const readmeQuery = useSuspenseQuery(queries.getModuleReadme(moduleId));
-  const readme = readmeQuery.data;
+  const readme = readmeQuery.data.html;
+  const readmeError = readmeQuery.data.error;
   const readmeLoading = readmeQuery.isLoading;
   const [showInstallModal, setShowInstallModal] = useState(false);
 
@@ -166,7 +185,7 @@ export function ModuleDetail() {
                 <div dangerouslySetInnerHTML={{ __html: readme }} />
               </div>
             ) : (
-              <p className="text-gray-600">No README available</p>
+              <p className="text-gray-600">{readmeErrorMessage(readmeError)}</p>
             )}
           </div>
         )}
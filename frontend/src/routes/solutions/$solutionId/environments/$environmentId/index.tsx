@@ -11,6 +11,7 @@ import { useEffect, useState } from "react";
 import { Terminal } from "../../../../../components/Terminal";
 import { Button } from "@stacc/prism-ui";
 import { DependencyGraph } from "../../../../../components/DependencyGraph";
+import { waitForOperation } from "../../../../../operations";
 
 export const Route = createFileRoute(
   "/solutions/$solutionId/environments/$environmentId/"
@@ -32,7 +33,7 @@ interface ComponentWithDetails {
   moduleId: string;
   moduleName: string;
   version: string;
-  status: "running" | "stopped" | "error";
+  status: "running" | "stopped" | "error" | "starting" | "stopping" | "deleting" | "syncing" | "deploying";
   selected?: boolean;
 }
 
@@ -43,6 +44,11 @@ interface ModuleComponents {
   components: ComponentWithDetails[];
 }
 
+// syncKey identifies a component the way SyncComponents expects it, component IDs may be
+// shared between modules
+const syncKey = (component: ComponentWithDetails) =>
+  `${component.moduleId}/${component.id}`;
+
 const componentTypeIcons: Record<ComponentType, string> = {
   Backend: "⚙️",
   Frontend: "🖥️",
@@ -66,6 +72,9 @@ export function EnvironmentDetail() {
   const [selectedForSync, setSelectedForSync] = useState<Set<string>>(
     new Set()
   );
+  const [syncing, setSyncing] = useState(false);
+  const [syncError, setSyncError] = useState<string | null>(null);
+  const [reloadKey, setReloadKey] = useState(0);
 
   useEffect(() => {
     const fetchEnvironment = async () => {
@@ -78,11 +87,19 @@ export function EnvironmentDetail() {
           return;
         }
 
-        const env = solution.environments.find((e) => e.id === environmentId);
-        if (!env) {
+        const found = solution.environments.find(
+          (e) => e.id === environmentId
+        );
+        if (!found) {
           setError("Environment not found");
           return;
         }
+        // Reflects what actually runs when environments run locally, the
+        // stored status is still worth showing if that can't be read
+        const env = await SolutionService.RefreshEnvironmentStatus(
+          solutionId,
+          environmentId
+        ).catch(() => found);
 
         setEnvironment(env);
 
@@ -99,7 +116,7 @@ export function EnvironmentDetail() {
                 moduleId: module.moduleId,
                 moduleName: moduleDetails.name,
                 version: module.version,
-                status: module.status as "running" | "stopped" | "error",
+                status: module.status as "running" | "stopped" | "error" | "starting" | "stopping" | "deleting" | "syncing" | "deploying",
               }));
 
             if (components.length > 0) {
@@ -131,7 +148,7 @@ export function EnvironmentDetail() {
     };
 
     fetchEnvironment();
-  }, [solutionId, environmentId]);
+  }, [solutionId, environmentId, reloadKey]);
 
   const fetchLogs = async (component: ComponentWithDetails) => {
     if (!solutionId || !environmentId) return;
@@ -159,17 +176,39 @@ export function EnvironmentDetail() {
   };
 
   const handleSyncSelected = async () => {
-    // TODO: Implement syncing of selected components
-    console.log("Syncing selected components:", Array.from(selectedForSync));
+    const componentIds = Array.from(selectedForSync);
+
+    setSyncing(true);
+    setSyncError(null);
+    try {
+      const operationId = await SolutionService.SyncComponents(
+        solutionId,
+        environmentId,
+        componentIds
+      );
+      await waitForOperation(operationId);
+      setSelectedForSync(new Set());
+    } catch (err) {
+      setSyncError(
+        err instanceof Error ? err.message : "Failed to sync components"
+      );
+    } finally {
+      setSyncing(false);
+      // Module statuses change with the outcome of the sync, failed or not
+      setReloadKey((key) => key + 1);
+    }
   };
 
-  const toggleComponentSelection = (componentId: string, checked: boolean) => {
+  const toggleComponentSelection = (
+    component: ComponentWithDetails,
+    checked: boolean
+  ) => {
     setSelectedForSync((prev) => {
       const newSet = new Set(prev);
       if (checked) {
-        newSet.add(componentId);
+        newSet.add(syncKey(component));
       } else {
-        newSet.delete(componentId);
+        newSet.delete(syncKey(component));
       }
       return newSet;
     });
@@ -182,9 +221,9 @@ export function EnvironmentDetail() {
       const newSet = new Set(prev);
       components.forEach((comp) => {
         if (checked) {
-          newSet.add(comp.id);
+          newSet.add(syncKey(comp));
         } else {
-          newSet.delete(comp.id);
+          newSet.delete(syncKey(comp));
         }
       });
       return newSet;
@@ -213,6 +252,11 @@ export function EnvironmentDetail() {
     running: "bg-green-50 text-green-700",
     stopped: "bg-gray-50 text-gray-700",
     error: "bg-red-50 text-red-700",
+    starting: "bg-blue-50 text-blue-700",
+    stopping: "bg-yellow-50 text-yellow-700",
+    deleting: "bg-orange-50 text-orange-700",
+    syncing: "bg-purple-50 text-purple-700",
+    deploying: "bg-indigo-50 text-indigo-700",
   };
 
   const logLevelColors = {
@@ -277,21 +321,28 @@ export function EnvironmentDetail() {
             <h2 className="text-xl font-semibold text-gray-800">Modules</h2>
             <Button
               onClick={handleSyncSelected}
-              label={`Sync (${selectedForSync.size})`}
-              disabled={selectedForSync.size === 0}
+              label={syncing ? "Syncing..." : `Sync (${selectedForSync.size})`}
+              disabled={selectedForSync.size === 0 || syncing}
               variant="primary"
               className="text-sm"
             />
           </div>
+          {syncError && (
+            <div className="bg-red-50 text-red-700 p-3 rounded-lg text-sm mb-4">
+              {syncError}
+            </div>
+          )}
           <div className="overflow-y-auto flex-1 pr-2">
             <div className="space-y-8">
               {moduleComponents.map((moduleComponent) => {
                 const components = moduleComponent.components;
                 const allModuleComponentsSelected = components.every(
-                  (comp: ComponentWithDetails) => selectedForSync.has(comp.id)
+                  (comp: ComponentWithDetails) =>
+                    selectedForSync.has(syncKey(comp))
                 );
                 const someModuleComponentsSelected = components.some(
-                  (comp: ComponentWithDetails) => selectedForSync.has(comp.id)
+                  (comp: ComponentWithDetails) =>
+                    selectedForSync.has(syncKey(comp))
                 );
 
                 return (
@@ -329,10 +380,11 @@ export function EnvironmentDetail() {
                     <div className="grid gap-3 pl-4 border-l-2 border-gray-200">
                       {moduleComponent.components.map((component) => (
                         <div
-                          key={component.id}
+                          key={syncKey(component)}
                           onClick={() => handleComponentSelect(component)}
                           className={`bg-white rounded-lg border ${
-                            selectedComponent?.id === component.id
+                            selectedComponent &&
+                            syncKey(selectedComponent) === syncKey(component)
                               ? "border-blue-500 ring-1 ring-blue-500"
                               : "border-gray-200"
                           } p-4 transition-all hover:border-blue-500 cursor-pointer group relative`}
@@ -344,12 +396,12 @@ export function EnvironmentDetail() {
                             <input
                               type="checkbox"
                               className="w-4 h-4 text-blue-600 rounded border-gray-300 focus:ring-blue-500"
-                              checked={selectedForSync.has(component.id)}
+                              checked={selectedForSync.has(syncKey(component))}
                               onChange={(
                                 e: React.ChangeEvent<HTMLInputElement>
                               ) =>
                                 toggleComponentSelection(
-                                  component.id,
+                                  component,
                                   e.target.checked
                                 )
                               }
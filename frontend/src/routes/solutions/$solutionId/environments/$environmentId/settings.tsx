@@ -2,7 +2,11 @@ import { createFileRoute, Link } from "@tanstack/react-router";
 import { useSuspenseQuery } from "@tanstack/react-query";
 import { queries } from "../../../../../queries";
 import { EnvironmentConfig } from "../../../../../components/EnvironmentConfig";
-import { Solution } from "../../../../../../bindings/changeme";
+import {
+  Solution,
+  SolutionService,
+} from "../../../../../../bindings/changeme";
+import { waitForOperation } from "../../../../../operations";
 
 export const Route = createFileRoute(
   "/solutions/$solutionId/environments/$environmentId/settings"
@@ -25,8 +29,22 @@ function EnvironmentSettingsPage() {
   }
 
   const handleSaveConfig = async (config: string) => {
-    // TODO: Implement save configuration
-    console.log("Saving config:", config);
+    const result = await SolutionService.SaveEnvironmentConfig(
+      solutionId,
+      environmentId,
+      config,
+      ""
+    );
+    if (!result.saved) {
+      throw new Error(
+        result.issues
+          .map((issue) =>
+            issue.line ? `Line ${issue.line}: ${issue.message}` : issue.message
+          )
+          .join("\n")
+      );
+    }
+    await waitForOperation(result.operationId);
   };
 
   return (
@@ -30,6 +30,11 @@ function EnvironmentCard({
     running: "bg-green-50 text-green-700",
     stopped: "bg-gray-50 text-gray-700",
     error: "bg-red-50 text-red-700",
+    starting: "bg-blue-50 text-blue-700",
+    stopping: "bg-yellow-50 text-yellow-700",
+    deleting: "bg-orange-50 text-orange-700",
+    syncing: "bg-purple-50 text-purple-700",
+    deploying: "bg-indigo-50 text-indigo-700",
   };
 
   const isDevelopment =
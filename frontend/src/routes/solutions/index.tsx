@@ -1,7 +1,9 @@
-import { createFileRoute, Link } from "@tanstack/react-router";
+import { useState } from "react";
+import { createFileRoute, Link, useNavigate } from "@tanstack/react-router";
 import { Button } from "@stacc/prism-ui";
 import { queries } from "../../queries";
 import { useSuspenseQuery } from "@tanstack/react-query";
+import { CreateSolutionModal } from "../../components/CreateSolutionModal";
 
 export const Route = createFileRoute("/solutions/")({
   component: SolutionList,
@@ -13,6 +15,8 @@ export const Route = createFileRoute("/solutions/")({
 export function SolutionList() {
   const solutionsQuery = useSuspenseQuery(queries.getSolutions());
   const solutions = solutionsQuery.data;
+  const navigate = useNavigate();
+  const [showCreateModal, setShowCreateModal] = useState(false);
 
   if (!solutions) {
     return <div>No solutions found</div>;
@@ -22,7 +26,10 @@ export function SolutionList() {
     <div className="p-8">
       <div className="flex justify-between items-center mb-8">
         <h1 className="text-3xl font-bold text-gray-800">Solutions</h1>
-        <Button label="New Solution" />
+        <Button
+          label="New Solution"
+          onClick={() => setShowCreateModal(true)}
+        />
       </div>
 
       <div className="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-3 gap-6">
@@ -63,6 +70,19 @@ export function SolutionList() {
           </Link>
         ))}
       </div>
+
+      {showCreateModal && (
+        <CreateSolutionModal
+          onClose={() => setShowCreateModal(false)}
+          onSolutionCreated={async (solution) => {
+            await solutionsQuery.refetch();
+            navigate({
+              to: "/solutions/$solutionId",
+              params: { solutionId: solution.id },
+            });
+          }}
+        />
+      )}
     </div>
   );
 }
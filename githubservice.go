@@ -1,74 +1,101 @@
 package main
 
 import (
-	"bytes"
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 	"strings"
 
 	"github.com/google/go-github/v69/github"
-	"github.com/yuin/goldmark"
-	"github.com/yuin/goldmark/extension"
 )
 
+// githubTokenEnv holds a GitHub token. Without one GitHub allows 60 requests an hour.
+const githubTokenEnv = "GITHUB_TOKEN"
+
 type GitHubService struct {
 	client *github.Client
-	md     goldmark.Markdown
+	md     *MarkdownRenderer
+	// authenticated is set when requests are made with a token
+	authenticated bool
 }
 
 func NewGitHubService() *GitHubService {
+	client := github.NewClient(nil)
+	token := os.Getenv(githubTokenEnv)
+	if token != "" {
+		client = client.WithAuthToken(token)
+	}
 	return &GitHubService{
-		client: github.NewClient(nil),
-		md:     goldmark.New(goldmark.WithExtensions(extension.GFM)),
+		client:        client,
+		md:            NewMarkdownRenderer(),
+		authenticated: token != "",
 	}
 }
 
-// GetReadmeFromURL extracts owner and repo from a GitHub URL and fetches the README
-func (s *GitHubService) GetReadmeFromURL(url string) (string, error) {
-	owner, repo := extractOwnerAndRepo(url)
-	if owner == "" || repo == "" {
-		return "", nil
-	}
-
-	ctx := context.Background()
-	readme, _, err := s.client.Repositories.GetReadme(ctx, owner, repo, &github.RepositoryContentGetOptions{})
+// GetReadmeFromURL parses a GitHub repository URL and fetches its README as HTML.
+// Failures are returned as a *LookupError describing why the README is unavailable.
+func (s *GitHubService) GetReadmeFromURL(rawURL string) (string, error) {
+	content, err := s.GetReadmeMarkdown(rawURL)
 	if err != nil {
-		if _, ok := err.(*github.RateLimitError); ok {
-			return "", err
-		}
-		return "", nil
+		return "", err
 	}
 
-	content, err := readme.GetContent()
+	// Convert markdown to sanitized HTML
+	return s.md.Render([]byte(content))
+}
+
+// GetReadmeMarkdown fetches the raw markdown README of a GitHub repository. URLs pointing
+// into a subdirectory fetch that directory's README instead of the repository root one.
+func (s *GitHubService) GetReadmeMarkdown(rawURL string) (string, error) {
+	repo, err := parseRepoURL(rawURL)
 	if err != nil {
-		return "", err
+		return "", newLookupError(ErrorKindInvalid, err, "%s", err.Error())
+	}
+	if !repo.IsGitHub() {
+		return "", newLookupError(ErrorKindInvalid, nil, "%s is not hosted on GitHub", rawURL)
 	}
 
-	// Convert markdown to HTML
-	var buf bytes.Buffer
-	if err := s.md.Convert([]byte(content), &buf); err != nil {
-		return "", err
+	ctx := context.Background()
+	readme, err := s.getReadme(ctx, repo)
+	if err != nil {
+		return "", classifyGitHubError(err, "README for "+repo.String())
 	}
 
-	// Add GitHub-style markdown CSS classes
-	html := `<div class="markdown-body">` + buf.String() + `</div>`
-	return html, nil
+	return readme.GetContent()
 }
 
-// extractOwnerAndRepo extracts the owner and repository name from a GitHub URL
-func extractOwnerAndRepo(url string) (string, string) {
-	// Remove trailing slash if present
-	url = strings.TrimSuffix(url, "/")
-
-	// Handle different GitHub URL formats
-	url = strings.TrimPrefix(url, "https://")
-	url = strings.TrimPrefix(url, "http://")
-	url = strings.TrimPrefix(url, "github.com/")
+// getReadme fetches the README of the repository root or, for monorepo URLs, of the subdirectory
+func (s *GitHubService) getReadme(ctx context.Context, repo repoURL) (*github.RepositoryContent, error) {
+	opts := &github.RepositoryContentGetOptions{Ref: repo.Ref}
+	if repo.Subpath == "" {
+		readme, _, err := s.client.Repositories.GetReadme(ctx, repo.Owner, repo.Repo, opts)
+		return readme, err
+	}
 
-	parts := strings.Split(url, "/")
-	if len(parts) < 2 {
-		return "", ""
+	// go-github has no helper for directory READMEs, so call the endpoint directly
+	u := fmt.Sprintf("repos/%s/%s/readme/%s", repo.Owner, repo.Repo, escapePath(repo.Subpath))
+	if repo.Ref != "" {
+		u += "?ref=" + url.QueryEscape(repo.Ref)
+	}
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return parts[0], parts[1]
-} 
+	readme := new(github.RepositoryContent)
+	if _, err := s.client.Do(ctx, req, readme); err != nil {
+		return nil, err
+	}
+	return readme, nil
+}
 
+// escapePath escapes each segment of a slash separated path
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
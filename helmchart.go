@@ -0,0 +1,459 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// bundledCharts are charts that ship with the app, modules refer to them by their path
+//
+//go:embed all:charts
+var bundledCharts embed.FS
+
+// chartFetchTimeout bounds downloading a chart from a repository
+const chartFetchTimeout = 2 * time.Minute
+
+// ModuleChart is the Helm chart a module is deployed with. Path is a chart directory or
+// archive, looked up in the charts bundled with the app before the file system. Charts in
+// a repository set Repository to an oci:// registry or an http(s) chart repository along
+// with Name and Version.
+type ModuleChart struct {
+	Path       string `json:"path,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Version    string `json:"version,omitempty"`
+}
+
+func (c ModuleChart) String() string {
+	if c.Path != "" {
+		return c.Path
+	}
+	return fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(c.Repository, "/"), c.Name, c.Version)
+}
+
+// validateChart checks that a chart reference is complete
+func validateChart(c ModuleChart) error {
+	switch {
+	case c.Path != "" && c.Repository != "":
+		return fmt.Errorf("chart must set either path or repository, not both")
+	case c.Path != "":
+		return nil
+	case c.Repository == "":
+		return fmt.Errorf("chart must set path or repository")
+	case c.Name == "" || c.Version == "":
+		return fmt.Errorf("chart in a repository needs a name and a version")
+	}
+	u, err := url.Parse(c.Repository)
+	if err != nil {
+		return fmt.Errorf("invalid chart repository %q: %w", c.Repository, err)
+	}
+	if u.Scheme != registry.OCIScheme && u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("chart repository %q must use oci, http or https", c.Repository)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("chart repository %q has no host", c.Repository)
+	}
+	return nil
+}
+
+// RenderedManifest is the output of one template of a chart
+type RenderedManifest struct {
+	Template string `json:"template"`
+	Content  string `json:"content"`
+}
+
+// ModuleManifests are the manifests a module's chart renders to in an environment. Chart
+// is the name and version of the chart that was rendered. Error is set instead of the
+// manifests if the chart couldn't be fetched or rendered.
+type ModuleManifests struct {
+	ModuleID  string             `json:"moduleId"`
+	Release   string             `json:"release"`
+	Chart     string             `json:"chart"`
+	Manifests []RenderedManifest `json:"manifests"`
+	Error     string             `json:"error"`
+}
+
+// ManifestPreview holds the rendered charts of the modules of an environment
+type ManifestPreview struct {
+	SolutionID    string            `json:"solutionId"`
+	EnvironmentID string            `json:"environmentId"`
+	Modules       []ModuleManifests `json:"modules"`
+}
+
+// chartRelease is what rendering a module's chart takes. It's gathered while holding s.mu
+// so the chart can be fetched and rendered without it.
+type chartRelease struct {
+	moduleID  string
+	chart     ModuleChart
+	namespace string
+	values    map[string]any
+}
+
+// chartCachePath is where a chart from a repository is kept once it's downloaded, below
+// the host and path of the repository so charts of the same name in different
+// repositories don't mix. An archive placed there stands in for the repository, which is
+// how tests and machines without access to it provide charts.
+func chartCachePath(c ModuleChart) []string {
+	elem := []string{"charts"}
+	if u, err := url.Parse(c.Repository); err == nil {
+		for _, part := range append([]string{u.Host}, strings.Split(u.Path, "/")...) {
+			if part != "" && part != "." && part != ".." {
+				elem = append(elem, part)
+			}
+		}
+	}
+	return append(elem, fmt.Sprintf("%s-%s.tgz", c.Name, c.Version))
+}
+
+// contextTransport binds the requests of clients that take no context to ctx, so they're
+// cancelled along with it
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+// loadChart loads a module's chart, downloading it if it's in a repository that hasn't
+// been fetched before
+func (s *SolutionService) loadChart(ctx context.Context, c ModuleChart) (*chart.Chart, error) {
+	if c.Path != "" {
+		name := path.Clean(c.Path)
+		if _, err := fs.Stat(bundledCharts, name); err == nil {
+			return loadBundledChart(name)
+		}
+		return loader.Load(c.Path)
+	}
+
+	data, ok, err := s.store.Read(chartCachePath(c)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached chart: %w", err)
+	}
+	if !ok {
+		if data, err = fetchChart(ctx, c); err != nil {
+			return nil, fmt.Errorf("failed to fetch chart %s: %w", c, err)
+		}
+		if err := s.store.Write(data, chartCachePath(c)...); err != nil {
+			return nil, fmt.Errorf("failed to cache chart: %w", err)
+		}
+	}
+	return loader.LoadArchive(bytes.NewReader(data))
+}
+
+// loadBundledChart loads a chart directory from the charts bundled with the app
+func loadBundledChart(dir string) (*chart.Chart, error) {
+	var files []*loader.BufferedFile
+	err := fs.WalkDir(bundledCharts, dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		data, err := bundledCharts.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		files = append(files, &loader.BufferedFile{Name: strings.TrimPrefix(name, dir+"/"), Data: data})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadFiles(files)
+}
+
+// fetchChart downloads the archive of a chart in a repository
+func fetchChart(ctx context.Context, c ModuleChart) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, chartFetchTimeout)
+	defer cancel()
+	if strings.HasPrefix(c.Repository, registry.OCIScheme+"://") {
+		client, err := registry.NewClient(registry.ClientOptHTTPClient(&http.Client{
+			Transport: contextTransport{ctx: ctx, base: http.DefaultTransport},
+		}))
+		if err != nil {
+			return nil, err
+		}
+		ref := strings.TrimSuffix(strings.TrimPrefix(c.Repository, registry.OCIScheme+"://"), "/")
+		result, err := client.Pull(fmt.Sprintf("%s/%s:%s", ref, c.Name, c.Version))
+		if err != nil {
+			return nil, err
+		}
+		return result.Chart.Data, nil
+	}
+
+	data, err := httpGet(ctx, strings.TrimSuffix(c.Repository, "/")+"/index.yaml")
+	if err != nil {
+		return nil, err
+	}
+	// The index can only be loaded from a file
+	file, err := os.CreateTemp("", "blocc-index-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	index, err := repo.LoadIndexFile(file.Name())
+	if err != nil {
+		return nil, err
+	}
+	version, err := index.Get(c.Name, c.Version)
+	if err != nil {
+		return nil, err
+	}
+	if len(version.URLs) == 0 {
+		return nil, fmt.Errorf("repository has no download for %s %s", c.Name, c.Version)
+	}
+	chartURL, err := repo.ResolveReferenceURL(c.Repository, version.URLs[0])
+	if err != nil {
+		return nil, err
+	}
+	return httpGet(ctx, chartURL)
+}
+
+// httpGet downloads a file from a chart repository
+func httpGet(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", rawURL, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// renderChart renders a chart the way helm install would, without a cluster. Partials,
+// notes and templates that render to nothing are left out.
+func renderChart(c *chart.Chart, release string, namespace string, values map[string]any) ([]RenderedManifest, error) {
+	if err := chartutil.ProcessDependenciesWithMerge(c, values); err != nil {
+		return nil, err
+	}
+	renderValues, err := chartutil.ToRenderValues(c, values, chartutil.ReleaseOptions{
+		Name:      release,
+		Namespace: namespace,
+		Revision:  1,
+		IsInstall: true,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	files, err := engine.Render(c, renderValues)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := []RenderedManifest{}
+	for name, content := range files {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" || strings.Trim(content, "-\n ") == "" {
+			continue
+		}
+		manifests = append(manifests, RenderedManifest{Template: name, Content: content})
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].Template < manifests[j].Template
+	})
+	return manifests, nil
+}
+
+// chartRelease gathers the chart and values of a module in env, or returns nil if the
+// module has no chart. The values are the module's configuration, with the global values,
+// the environment and the planned components under the keys global, blocc and components.
+// Vault secrets are resolved for deployment and masked otherwise, see materializeValues.
+func (s *SolutionService) chartRelease(solutionId string, env Environment, moduleId string, resolveSecrets bool) (*chartRelease, error) {
+	if s.modules == nil {
+		return nil, nil
+	}
+	module := s.modules.findModule(moduleId)
+	if module == nil || module.Chart == nil {
+		return nil, nil
+	}
+	solutionDoc, err := s.loadSolutionDocument(solutionId)
+	if err != nil {
+		return nil, err
+	}
+	envDoc, err := s.loadEnvironmentDocument(solutionId, env)
+	if err != nil {
+		return nil, err
+	}
+	effective := s.effectiveConfig(solutionId, env, solutionDoc, envDoc)
+
+	var values map[string]any
+	if resolveSecrets {
+		values, err = s.materializeValues(solutionId, effective.moduleValues(moduleId))
+	} else {
+		values, err = replaceSecretRefs("", effective.moduleValues(moduleId), func(path string, ref SecretRef, err error) (any, error) {
+			switch {
+			case err != nil:
+				return nil, fmt.Errorf("%s: %w", path, err)
+			case ref.Kubernetes != "":
+				return ref.value(), nil
+			}
+			return secretMask, nil
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	global := make(map[string]any, len(effective.Global))
+	for key, value := range effective.Global {
+		global[key] = value.Value
+	}
+	components := make(map[string]any)
+	for _, component := range effective.Resources.Components {
+		if component.ModuleID != moduleId {
+			continue
+		}
+		replicas := component.Replicas
+		if env.Status != EnvironmentStatusRunning {
+			replicas = 0
+		}
+		components[component.ComponentID] = map[string]any{
+			"name":     component.ComponentName,
+			"type":     string(component.Type),
+			"replicas": replicas,
+			"resources": map[string]any{
+				"requests": component.Requests.values(),
+				"limits":   component.Limits.values(),
+			},
+		}
+	}
+	version := ""
+	for _, installed := range env.Modules {
+		if installed.ModuleID == moduleId {
+			version = installed.Version
+		}
+	}
+
+	values["global"] = global
+	values["components"] = components
+	values["blocc"] = map[string]any{
+		"solution":    solutionId,
+		"environment": env.ID,
+		"namespace":   env.Namespace,
+		"module":      moduleId,
+		"version":     version,
+	}
+	return &chartRelease{moduleID: moduleId, chart: *module.Chart, namespace: env.Namespace, values: values}, nil
+}
+
+// values returns the quantities that are set, as chart values
+func (q ResourceQuantities) values() map[string]any {
+	values := make(map[string]any)
+	if q.CPU != "" {
+		values["cpu"] = q.CPU
+	}
+	if q.Memory != "" {
+		values["memory"] = q.Memory
+	}
+	return values
+}
+
+// render fetches and renders the chart of a release
+func (s *SolutionService) render(ctx context.Context, release chartRelease) (ModuleManifests, error) {
+	manifests := ModuleManifests{ModuleID: release.moduleID, Release: release.moduleID, Manifests: []RenderedManifest{}}
+	c, err := s.loadChart(ctx, release.chart)
+	if err != nil {
+		return manifests, err
+	}
+	manifests.Chart = fmt.Sprintf("%s-%s", c.Name(), c.Metadata.Version)
+	rendered, err := renderChart(c, release.moduleID, release.namespace, release.values)
+	if err != nil {
+		return manifests, fmt.Errorf("failed to render chart %s: %w", manifests.Chart, err)
+	}
+	manifests.Manifests = rendered
+	return manifests, nil
+}
+
+// PreviewManifests renders the charts of an environment's modules without deploying
+// anything, showing what would be applied. Secrets are masked. With a moduleId only that
+// module is rendered; a module that isn't installed yet is rendered as it would be
+// installed. Modules without a chart are left out.
+func (s *SolutionService) PreviewManifests(solutionId string, environmentId string, moduleId string) (ManifestPreview, error) {
+	releases, err := s.previewReleases(solutionId, environmentId, moduleId)
+	if err != nil {
+		return ManifestPreview{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chartFetchTimeout)
+	defer cancel()
+	preview := ManifestPreview{SolutionID: solutionId, EnvironmentID: environmentId, Modules: []ModuleManifests{}}
+	for _, release := range releases {
+		manifests, err := s.render(ctx, release)
+		if err != nil {
+			manifests.Error = err.Error()
+		}
+		preview.Modules = append(preview.Modules, manifests)
+	}
+	return preview, nil
+}
+
+func (s *SolutionService) previewReleases(solutionId string, environmentId string, moduleId string) ([]chartRelease, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return nil, err
+	}
+	target := env.clone()
+	var moduleIds []string
+	for _, module := range target.Modules {
+		if moduleId == "" || module.ModuleID == moduleId {
+			moduleIds = append(moduleIds, module.ModuleID)
+		}
+	}
+	if moduleId != "" && len(moduleIds) == 0 {
+		var module *Module
+		if s.modules != nil {
+			module = s.modules.findModule(moduleId)
+		}
+		if module == nil {
+			return nil, fmt.Errorf("module not found")
+		}
+		target.Modules = append(target.Modules, EnvironmentModule{ModuleID: moduleId, Version: module.Version, Status: target.Status})
+		moduleIds = []string{moduleId}
+	}
+
+	var releases []chartRelease
+	for _, id := range moduleIds {
+		release, err := s.chartRelease(solutionId, target, id, false)
+		if err != nil {
+			return nil, err
+		}
+		if release != nil {
+			releases = append(releases, *release)
+		}
+	}
+	return releases, nil
+}
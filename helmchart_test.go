@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// TestRenderBundledChart renders the bundled chart with the configuration and components
+// of an environment
+func TestRenderBundledChart(t *testing.T) {
+	s := newTestSolutionService(t)
+	const solutionId, environmentId = "demo-solution", "dev-1"
+	config, err := s.GetEnvironmentConfig(solutionId, environmentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.Replace(config.YAML, "    control-panel:\n      values: {}\n", "    control-panel:\n      values:\n        theme: dark\n", 1)
+	result, err := s.SaveEnvironmentConfig(solutionId, environmentId, content, "Dark theme")
+	if err != nil || !result.Saved {
+		t.Fatalf("SaveEnvironmentConfig: %v %v", err, result.Issues)
+	}
+	waitForOperations(t, s)
+
+	env, err := s.environment(solutionId, environmentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := s.componentPlan(solutionId, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		status EnvironmentStatus
+	}{
+		{"running", EnvironmentStatusRunning},
+		{"stopped", EnvironmentStatusStopped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := env.clone()
+			env.Status = tt.status
+			release, err := s.chartRelease(solutionId, env, "control-panel", false)
+			if err != nil || release == nil {
+				t.Fatalf("chartRelease: %v %v", release, err)
+			}
+			manifests, err := s.render(context.Background(), *release)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if manifests.Chart != "blocc-module-0.1.0" {
+				t.Errorf("chart is %q, want blocc-module-0.1.0", manifests.Chart)
+			}
+
+			rendered := make(map[string]string)
+			for _, manifest := range manifests.Manifests {
+				rendered[filepath.Base(manifest.Template)] = manifest.Content
+			}
+			if secret := rendered["secret.yaml"]; !strings.Contains(secret, "theme: dark") {
+				t.Errorf("config Secret doesn't hold the environment's values:\n%s", secret)
+			}
+			components := rendered["components.yaml"]
+			for _, want := range []string{
+				"blocc.dev/environment: dev-1",
+				"app.kubernetes.io/part-of: demo-solution",
+			} {
+				if !strings.Contains(components, want) {
+					t.Errorf("components don't contain %q", want)
+				}
+			}
+			planned := 0
+			for _, component := range plan {
+				if component.ModuleID != "control-panel" {
+					continue
+				}
+				planned++
+				replicas := component.Replicas
+				if tt.status != EnvironmentStatusRunning {
+					replicas = 0
+				}
+				for _, want := range []string{
+					fmt.Sprintf("name: control-panel-%s\n", component.ComponentID),
+					fmt.Sprintf("image: registry.stacc.dev/control-panel/%s:1.0.0\n", component.ComponentID),
+					fmt.Sprintf("replicas: %d\n", replicas),
+				} {
+					if !strings.Contains(components, want) {
+						t.Errorf("components don't contain %q:\n%s", want, components)
+					}
+				}
+			}
+			if planned == 0 || strings.Count(components, "kind: Deployment") != planned {
+				t.Errorf("rendered %d Deployments for %d planned components", strings.Count(components, "kind: Deployment"), planned)
+			}
+		})
+	}
+}
+
+func TestChartCachePath(t *testing.T) {
+	tests := []struct {
+		repository string
+		want       string
+	}{
+		{"oci://registry.stacc.dev/charts", "charts/registry.stacc.dev/charts/flow-15.4.0.tgz"},
+		{"oci://registry.example.com/charts/", "charts/registry.example.com/charts/flow-15.4.0.tgz"},
+		{"https://charts.example.com", "charts/charts.example.com/flow-15.4.0.tgz"},
+		{"https://charts.example.com/../../secrets", "charts/charts.example.com/secrets/flow-15.4.0.tgz"},
+	}
+	for _, tt := range tests {
+		got := strings.Join(chartCachePath(ModuleChart{Repository: tt.repository, Name: "flow", Version: "15.4.0"}), "/")
+		if got != tt.want {
+			t.Errorf("chartCachePath(%q) = %q, want %q", tt.repository, got, tt.want)
+		}
+	}
+}
+
+// TestLoadCachedChart loads a chart from an archive standing in for its repository
+func TestLoadCachedChart(t *testing.T) {
+	s := newTestSolutionService(t)
+	c, err := loadBundledChart("charts/blocc-module")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Metadata.Name, c.Metadata.Version = "flow", "15.4.0"
+	chart := ModuleChart{Repository: "oci://registry.stacc.dev/charts", Name: "flow", Version: "15.4.0"}
+	dir := chartCachePath(chart)
+	if _, err := chartutil.Save(c, s.store.path(dir[:len(dir)-1]...)); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := s.loadChart(context.Background(), chart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Name() != "flow" || loaded.Metadata.Version != "15.4.0" {
+		t.Errorf("loaded %s %s, want flow 15.4.0", loaded.Name(), loaded.Metadata.Version)
+	}
+}
+
+// TestFetchChartCancelled fetches charts with a context that is cancelled before or while
+// the repository is reached, the fetch has to give up right away
+func TestFetchChartCancelled(t *testing.T) {
+	// A repository that never answers
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer hanging.Close()
+
+	tests := []struct {
+		name       string
+		repository string
+		cancelled  time.Duration
+	}{
+		{"oci", "oci://registry.stacc.dev/charts", 0},
+		{"https", "https://charts.stacc.dev/stable", 0},
+		{"hanging oci", "oci://" + strings.TrimPrefix(hanging.URL, "http://") + "/charts", 100 * time.Millisecond},
+		{"hanging http", hanging.URL + "/charts", 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancelled == 0 {
+				cancel()
+			} else {
+				time.AfterFunc(tt.cancelled, cancel)
+			}
+			start := time.Now()
+			_, err := fetchChart(ctx, ModuleChart{Repository: tt.repository, Name: "flow", Version: "15.4.0"})
+			if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+				t.Errorf("fetchChart with a cancelled context returned %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("fetchChart took %s with a cancelled context", elapsed)
+			}
+		})
+	}
+}
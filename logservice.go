@@ -7,8 +7,9 @@ import (
 )
 
 type LogService struct {
-	// In a real implementation, this would connect to a log aggregation service
-	// For now, we'll generate mock logs
+	// solutions reads logs from where components actually run if its deployer can,
+	// otherwise we generate mock logs
+	solutions *SolutionService
 }
 
 type LogEntry struct {
@@ -23,6 +24,12 @@ func NewLogService() *LogService {
 
 // GetComponentLogs returns logs for a specific component in an environment
 func (s *LogService) GetComponentLogs(solutionId string, environmentId string, componentId string) ([]LogEntry, error) {
+	if s.solutions != nil {
+		if logs, ok, err := s.solutions.componentLogs(solutionId, environmentId, componentId); ok {
+			return logs, err
+		}
+	}
+
 	logs := []LogEntry{}
 	
 	// Generate some mock logs
@@ -128,7 +135,7 @@ func (s *LogService) GetComponentLogs(solutionId string, environmentId string, c
 		logs = append(logs, LogEntry{
 			Timestamp: timestamp,
 			Level:     level,
-			Message:   message,
+			Message:   secretMasker.Mask(message),
 		})
 	}
 
@@ -20,9 +20,14 @@ var assets embed.FS
 // logs any error that might occur.
 func main() {
 	// Create and initialize our services
-	moduleService := NewModuleService()
-	solutionService := NewSolutionService()
+	operationService := NewOperationService()
+	solutionService := NewSolutionService(operationService)
+	moduleService := NewModuleService(solutionService)
+	// Solutions validate environment configuration against the module catalog
+	solutionService.modules = moduleService
 	logService := NewLogService()
+	// Logs come from the environments' deployer when it can read them
+	logService.solutions = solutionService
 	systemService := NewSystemService()
 
 	// Create a new Wails application by providing the necessary options.
@@ -38,6 +43,7 @@ func main() {
 			application.NewService(solutionService),
 			application.NewService(logService),
 			application.NewService(systemService),
+			application.NewService(operationService),
 		},
 		Assets: application.AssetOptions{
 			Handler: application.AssetFileServerFS(assets),
@@ -63,6 +69,9 @@ func main() {
 		URL:              "/",
 	})
 
+	// Operations report their progress to the frontend as events
+	operationService.emit = app.EmitEvent
+
 
 	// Run the application. This blocks until the application has been exited.
 	err := app.Run()
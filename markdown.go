@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	htmlstd "html"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// highlightStyle is the chroma style used for fenced code blocks
+const highlightStyle = "github"
+
+// highlightClassPrefix namespaces chroma's CSS classes so they can't clash with the app's own styles
+const highlightClassPrefix = "hl-"
+
+// MarkdownRenderer converts README markdown into sanitized HTML for the webview
+type MarkdownRenderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+	css    string
+}
+
+func NewMarkdownRenderer() *MarkdownRenderer {
+	formatOptions := []html.Option{
+		html.WithClasses(true),
+		html.ClassPrefix(highlightClassPrefix),
+	}
+
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			extension.Footnote,
+			alertExtension{},
+			mermaidExtension{},
+			highlighting.NewHighlighting(
+				highlighting.WithStyle(highlightStyle),
+				highlighting.WithFormatOptions(formatOptions...),
+			),
+		),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)
+
+	// The stylesheet is generated by us rather than taken from the README, so it
+	// is emitted next to the sanitized output instead of passing through the policy
+	var css bytes.Buffer
+	if err := html.New(formatOptions...).WriteCSS(&css, styles.Get(highlightStyle)); err != nil {
+		css.Reset()
+	}
+
+	return &MarkdownRenderer{
+		md:     md,
+		policy: newMarkdownPolicy(),
+		css:    css.String(),
+	}
+}
+
+// Render converts markdown to HTML wrapped in a GitHub-style markdown-body container
+func (r *MarkdownRenderer) Render(source []byte) (string, error) {
+	var buf bytes.Buffer
+	if err := r.md.Convert(source, &buf); err != nil {
+		return "", err
+	}
+
+	body := r.policy.SanitizeBytes(buf.Bytes())
+
+	var out strings.Builder
+	if r.css != "" {
+		out.WriteString("<style>")
+		out.WriteString(r.css)
+		out.WriteString("</style>")
+	}
+	out.WriteString(`<div class="markdown-body">`)
+	out.Write(body)
+	out.WriteString(`</div>`)
+	return out.String(), nil
+}
+
+// PlainText converts markdown to text with all markup removed, for indexing
+func (r *MarkdownRenderer) PlainText(source []byte) (string, error) {
+	var buf bytes.Buffer
+	if err := r.md.Convert(source, &buf); err != nil {
+		return "", err
+	}
+	text := bluemonday.StrictPolicy().SanitizeBytes(buf.Bytes())
+	return htmlstd.UnescapeString(string(text)), nil
+}
+
+// newMarkdownPolicy returns the sanitization policy for rendered READMEs. It starts
+// from bluemonday's user generated content policy and only adds what the
+// markdown extensions above need to render.
+func newMarkdownPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+
+	// Highlighting, alerts, mermaid and footnotes all rely on plain class names
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^[\w\- ]+$`)).Globally()
+
+	// GFM task list items
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^(|checked|disabled)$`)).OnElements("input")
+
+	// Footnote back references
+	p.AllowAttrs("role").Matching(regexp.MustCompile(`^doc-[a-z]+$`)).OnElements("a", "div", "section")
+
+	return p
+}
+
+// alertKinds maps the GitHub alert markers to the title shown above the alert
+var alertKinds = map[string]string{
+	"note":      "Note",
+	"tip":       "Tip",
+	"important": "Important",
+	"warning":   "Warning",
+	"caution":   "Caution",
+}
+
+var alertMarker = regexp.MustCompile(`(?i)^\[!(note|tip|important|warning|caution)\]\s*$`)
+
+var kindAlert = ast.NewNodeKind("Alert")
+
+// alertBlock is a GitHub alert, i.e. a blockquote starting with a `[!NOTE]` style marker
+type alertBlock struct {
+	ast.BaseBlock
+	alert string
+}
+
+func (n *alertBlock) Kind() ast.NodeKind {
+	return kindAlert
+}
+
+func (n *alertBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Alert": n.alert}, nil)
+}
+
+type alertExtension struct{}
+
+func (alertExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(alertTransformer{}, 100)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(alertRenderer{}, 100)))
+}
+
+type alertTransformer struct{}
+
+// Transform replaces blockquotes whose first line is an alert marker with an alertBlock
+func (alertTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+
+	var quotes []*ast.Blockquote
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if quote, ok := n.(*ast.Blockquote); ok && entering {
+			quotes = append(quotes, quote)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, quote := range quotes {
+		para, ok := quote.FirstChild().(*ast.Paragraph)
+		if !ok || para.Lines().Len() == 0 {
+			continue
+		}
+
+		first := para.Lines().At(0)
+		match := alertMarker.FindSubmatch(bytes.TrimSpace(first.Value(source)))
+		if match == nil {
+			continue
+		}
+
+		// Drop the marker line, keeping whatever text follows it
+		for child := para.FirstChild(); child != nil; {
+			next := child.NextSibling()
+			t, ok := child.(*ast.Text)
+			if !ok || t.Segment.Start >= first.Stop {
+				break
+			}
+			para.RemoveChild(para, child)
+			child = next
+		}
+		if !para.HasChildren() {
+			quote.RemoveChild(quote, para)
+		}
+
+		alert := &alertBlock{alert: strings.ToLower(string(match[1]))}
+		for child := quote.FirstChild(); child != nil; {
+			next := child.NextSibling()
+			alert.AppendChild(alert, child)
+			child = next
+		}
+		quote.Parent().ReplaceChild(quote.Parent(), quote, alert)
+	}
+}
+
+type alertRenderer struct{}
+
+func (alertRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindAlert, func(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+		n := node.(*alertBlock)
+		if entering {
+			fmt.Fprintf(w, "<div class=\"markdown-alert markdown-alert-%s\">\n", n.alert)
+			fmt.Fprintf(w, "<p class=\"markdown-alert-title\">%s</p>\n", alertKinds[n.alert])
+		} else {
+			_, _ = w.WriteString("</div>\n")
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+var kindMermaid = ast.NewNodeKind("Mermaid")
+
+// mermaidBlock is a ```mermaid fenced code block. It is passed through untouched
+// so the frontend can render the diagram instead of it being highlighted as code.
+type mermaidBlock struct {
+	ast.BaseBlock
+}
+
+func (n *mermaidBlock) Kind() ast.NodeKind {
+	return kindMermaid
+}
+
+func (n *mermaidBlock) IsRaw() bool {
+	return true
+}
+
+func (n *mermaidBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+type mermaidExtension struct{}
+
+func (mermaidExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(mermaidTransformer{}, 100)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(mermaidRenderer{}, 100)))
+}
+
+type mermaidTransformer struct{}
+
+// Transform swaps mermaid fenced code blocks for mermaidBlocks before the highlighter sees them
+func (mermaidTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+
+	var blocks []*ast.FencedCodeBlock
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if block, ok := n.(*ast.FencedCodeBlock); ok && entering {
+			if string(block.Language(source)) == "mermaid" {
+				blocks = append(blocks, block)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, block := range blocks {
+		diagram := &mermaidBlock{}
+		diagram.SetLines(block.Lines())
+		block.Parent().ReplaceChild(block.Parent(), block, diagram)
+	}
+}
+
+type mermaidRenderer struct{}
+
+func (mermaidRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindMermaid, func(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		_, _ = w.WriteString(`<pre class="mermaid">`)
+		lines := node.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			line := lines.At(i)
+			_, _ = w.Write(util.EscapeHTML(line.Value(source)))
+		}
+		_, _ = w.WriteString("</pre>\n")
+		return ast.WalkSkipChildren, nil
+	})
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// ModuleFilter narrows down the module catalog. Values within one facet are OR'ed,
+// different facets are AND'ed. Empty facets don't filter.
+type ModuleFilter struct {
+	Query          string          `json:"query"`
+	Organizations  []string        `json:"organizations"`
+	Maintainers    []string        `json:"maintainers"`
+	Licenses       []string        `json:"licenses"`
+	Tags           []string        `json:"tags"`
+	ComponentTypes []ComponentType `json:"componentTypes"`
+	SolutionIDs    []string        `json:"solutionIds"`
+}
+
+type FacetValue struct {
+	Value    string `json:"value"`
+	Label    string `json:"label"`
+	Count    int    `json:"count"`
+	Selected bool   `json:"selected"`
+}
+
+type ModuleFacets struct {
+	Organizations  []FacetValue `json:"organizations"`
+	Maintainers    []FacetValue `json:"maintainers"`
+	Licenses       []FacetValue `json:"licenses"`
+	Tags           []FacetValue `json:"tags"`
+	ComponentTypes []FacetValue `json:"componentTypes"`
+	Solutions      []FacetValue `json:"solutions"`
+}
+
+type ModuleQueryResult struct {
+	Results []ModuleSearchResult `json:"results"`
+	Total   int                  `json:"total"`
+	Facets  ModuleFacets         `json:"facets"`
+}
+
+// moduleFacet extracts the values of one facet from a module and the selected values from a filter
+type moduleFacet struct {
+	name     string
+	values   func(m Module, installed map[string]map[string]bool) []string
+	selected func(f ModuleFilter) []string
+}
+
+var (
+	facetOrganization = moduleFacet{
+		name:     "organizations",
+		values:   func(m Module, _ map[string]map[string]bool) []string { return []string{m.Organization} },
+		selected: func(f ModuleFilter) []string { return f.Organizations },
+	}
+	facetMaintainer = moduleFacet{
+		name:     "maintainers",
+		values:   func(m Module, _ map[string]map[string]bool) []string { return []string{m.Maintainer} },
+		selected: func(f ModuleFilter) []string { return f.Maintainers },
+	}
+	facetLicense = moduleFacet{
+		name:     "licenses",
+		values:   func(m Module, _ map[string]map[string]bool) []string { return []string{m.Attributes.License} },
+		selected: func(f ModuleFilter) []string { return f.Licenses },
+	}
+	facetTag = moduleFacet{
+		name:     "tags",
+		values:   func(m Module, _ map[string]map[string]bool) []string { return m.Tags },
+		selected: func(f ModuleFilter) []string { return f.Tags },
+	}
+	facetComponentType = moduleFacet{
+		name: "componentTypes",
+		values: func(m Module, _ map[string]map[string]bool) []string {
+			types := make([]string, 0, len(m.Components))
+			for _, component := range m.Components {
+				types = append(types, string(component.Type))
+			}
+			return types
+		},
+		selected: func(f ModuleFilter) []string {
+			types := make([]string, len(f.ComponentTypes))
+			for i, t := range f.ComponentTypes {
+				types[i] = string(t)
+			}
+			return types
+		},
+	}
+	facetSolution = moduleFacet{
+		name: "solutions",
+		values: func(m Module, installed map[string]map[string]bool) []string {
+			var solutions []string
+			for solutionID, modules := range installed {
+				if modules[m.ID] {
+					solutions = append(solutions, solutionID)
+				}
+			}
+			return solutions
+		},
+		selected: func(f ModuleFilter) []string { return f.SolutionIDs },
+	}
+)
+
+var moduleFacets = []moduleFacet{facetOrganization, facetMaintainer, facetLicense, facetTag, facetComponentType, facetSolution}
+
+// matches reports whether the module has one of the selected values, or nothing is selected
+func (f moduleFacet) matches(m Module, filter ModuleFilter, installed map[string]map[string]bool) bool {
+	selected := f.selected(filter)
+	if len(selected) == 0 {
+		return true
+	}
+	for _, value := range f.values(m, installed) {
+		for _, want := range selected {
+			if strings.EqualFold(value, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// QueryModules filters the catalog by free text and facets. The facet counts of each
+// facet are computed with every other facet applied, so selecting a value doesn't hide
+// the alternatives in the same facet.
+func (s *ModuleService) QueryModules(filter ModuleFilter) ModuleQueryResult {
+	installed := s.installedModules()
+
+	// Free text decides the candidate set and the order
+	var candidates []ModuleSearchResult
+	if strings.TrimSpace(filter.Query) == "" {
+		for _, module := range s.modules {
+			candidates = append(candidates, ModuleSearchResult{Module: module.ToResponse()})
+		}
+	} else {
+		candidates = s.SearchModuleMatches(filter.Query)
+	}
+
+	modules := make(map[string]Module, len(s.modules))
+	for _, module := range s.modules {
+		modules[module.ID] = module
+	}
+
+	counts := make(map[string]*facetCounts, len(moduleFacets))
+	for _, facet := range moduleFacets {
+		counts[facet.name] = newFacetCounts()
+	}
+
+	results := []ModuleSearchResult{}
+	for _, candidate := range candidates {
+		module := modules[candidate.Module.ID]
+
+		failed := -1
+		for i, facet := range moduleFacets {
+			if facet.matches(module, filter, installed) {
+				continue
+			}
+			if failed >= 0 {
+				failed = len(moduleFacets)
+				break
+			}
+			failed = i
+		}
+
+		switch {
+		case failed < 0:
+			results = append(results, candidate)
+			for _, facet := range moduleFacets {
+				counts[facet.name].add(facet.values(module, installed))
+			}
+		case failed < len(moduleFacets):
+			// Only this facet excludes the module, so it still counts towards that facet's values
+			facet := moduleFacets[failed]
+			counts[facet.name].add(facet.values(module, installed))
+		}
+	}
+
+	solutionNames := make(map[string]string)
+	for _, solution := range s.solutionList() {
+		solutionNames[solution.ID] = solution.Name
+	}
+
+	return ModuleQueryResult{
+		Results: results,
+		Total:   len(results),
+		Facets: ModuleFacets{
+			Organizations:  counts[facetOrganization.name].facetValues(facetOrganization.selected(filter), nil),
+			Maintainers:    counts[facetMaintainer.name].facetValues(facetMaintainer.selected(filter), nil),
+			Licenses:       counts[facetLicense.name].facetValues(facetLicense.selected(filter), nil),
+			Tags:           counts[facetTag.name].facetValues(facetTag.selected(filter), nil),
+			ComponentTypes: counts[facetComponentType.name].facetValues(facetComponentType.selected(filter), nil),
+			Solutions:      counts[facetSolution.name].facetValues(facetSolution.selected(filter), solutionNames),
+		},
+	}
+}
+
+// facetCounts counts the values of a facet. Values are matched case-insensitively like
+// filters are, the first spelling seen is the one shown.
+type facetCounts struct {
+	counts map[string]int
+	values map[string]string
+}
+
+func newFacetCounts() *facetCounts {
+	return &facetCounts{counts: make(map[string]int), values: make(map[string]string)}
+}
+
+// add counts each distinct, non-empty value once
+func (c *facetCounts) add(values []string) {
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if _, ok := c.values[key]; !ok {
+			c.values[key] = value
+		}
+		c.counts[key]++
+	}
+}
+
+// facetValues turns the counts into facet values sorted by count, keeping selected values
+// that no longer match anything so they can be deselected
+func (c *facetCounts) facetValues(selected []string, labels map[string]string) []FacetValue {
+	isSelected := make(map[string]bool, len(selected))
+	for _, value := range selected {
+		key := strings.ToLower(value)
+		isSelected[key] = true
+		if _, ok := c.values[key]; !ok {
+			c.values[key] = value
+			c.counts[key] = 0
+		}
+	}
+
+	values := make([]FacetValue, 0, len(c.counts))
+	for key, count := range c.counts {
+		value := c.values[key]
+		label := value
+		if l, ok := labels[value]; ok {
+			label = l
+		}
+		values = append(values, FacetValue{
+			Value:    value,
+			Label:    label,
+			Count:    count,
+			Selected: isSelected[key],
+		})
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Label < values[j].Label
+	})
+	return values
+}
+
+// installedModules returns the IDs of the modules used by each solution, either
+// declared on the solution or installed in one of its environments
+func (s *ModuleService) installedModules() map[string]map[string]bool {
+	installed := make(map[string]map[string]bool)
+	for _, solution := range s.solutionList() {
+		modules := make(map[string]bool)
+		for _, module := range solution.Modules {
+			modules[module.ModuleID] = true
+		}
+		for _, env := range solution.Environments {
+			for _, module := range env.Modules {
+				modules[module.ModuleID] = true
+			}
+		}
+		installed[solution.ID] = modules
+	}
+	return installed
+}
+
+func (s *ModuleService) solutionList() []Solution {
+	if s.solutions == nil {
+		return nil
+	}
+	return s.solutions.GetSolutions()
+}
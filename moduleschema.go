@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// schemaViolation is a value that doesn't match a module's configuration schema. Path
+// is a dotted path relative to the module's values, empty for the values themselves.
+type schemaViolation struct {
+	Path    string
+	Message string
+}
+
+// compileConfigSchema compiles the JSON Schema a module declares for its configuration values
+func compileConfigSchema(moduleID string, schema json.RawMessage) (*jsonschema.Schema, error) {
+	url := "blocc://modules/" + moduleID + "/config.schema.json"
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.ExtractAnnotations = true
+	if err := compiler.AddResource(url, bytes.NewReader(schema)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(url)
+}
+
+// compileConfigSchemas compiles the configuration schemas of all modules that declare one.
+// Modules with a broken schema are left out, catalog validation reports them.
+func compileConfigSchemas(modules []Module) map[string]*jsonschema.Schema {
+	schemas := make(map[string]*jsonschema.Schema)
+	for _, module := range modules {
+		if len(module.ConfigSchema) == 0 {
+			continue
+		}
+		if schema, err := compileConfigSchema(module.ID, module.ConfigSchema); err == nil {
+			schemas[module.ID] = schema
+		}
+	}
+	return schemas
+}
+
+// moduleConfigSchema returns the compiled configuration schema of a module, or nil if it has none
+func (s *ModuleService) moduleConfigSchema(id string) *jsonschema.Schema {
+	return s.configSchemas[id]
+}
+
+// schemaDefaults returns the defaults the schema declares for top level properties
+func schemaDefaults(schema *jsonschema.Schema) map[string]any {
+	defaults := make(map[string]any)
+	if schema == nil {
+		return defaults
+	}
+	for key, property := range schema.Properties {
+		if property.Default != nil {
+			defaults[key] = property.Default
+		}
+	}
+	return defaults
+}
+
+// validateSchemaValues validates module configuration values against the module's schema
+func validateSchemaValues(schema *jsonschema.Schema, values map[string]any) ([]schemaViolation, error) {
+	// The validator expects values as produced by encoding/json, YAML decodes numbers as Go ints
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var instance any
+	if err := decoder.Decode(&instance); err != nil {
+		return nil, err
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	var violations []schemaViolation
+	var collect func(e *jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				collect(cause)
+			}
+			return
+		}
+		violations = append(violations, schemaViolation{
+			Path:    pointerToPath(e.InstanceLocation),
+			Message: e.Message,
+		})
+	}
+	collect(validationErr)
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+	return violations, nil
+}
+
+// pointerToPath turns a JSON pointer such as /db/port into db.port
+func pointerToPath(pointer string) string {
+	if pointer == "" || pointer == "/" {
+		return ""
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+	return strings.Join(segments, ".")
+}
+
+// checkConfigSchema returns an error if the module declares a configuration schema that doesn't compile
+func checkConfigSchema(module Module) error {
+	if len(module.ConfigSchema) == 0 {
+		return nil
+	}
+	if _, err := compileConfigSchema(module.ID, module.ConfigSchema); err != nil {
+		return fmt.Errorf("configuration schema is invalid: %w", err)
+	}
+	return nil
+}
@@ -1,8 +1,16 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"log"
+	"maps"
+	"slices"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
 type ModuleAttributes struct {
@@ -48,6 +56,8 @@ type Module struct {
 	Dependencies   []ModuleDependency `json:"dependencies"`
 	Attributes     ModuleAttributes   `json:"attributes"`
 	Components     []ModuleComponent  `json:"components"`
+	ConfigSchema   json.RawMessage    `json:"configSchema,omitempty"`
+	Chart          *ModuleChart       `json:"chart,omitempty"`
 }
 
 // ModuleResponse is used for API responses to ensure consistent JSON serialization
@@ -63,30 +73,45 @@ type ModuleResponse struct {
 	Dependencies   []ModuleDependency `json:"dependencies"`
 	Attributes     ModuleAttributes   `json:"attributes"`
 	Components     []ModuleComponent  `json:"components"`
+	ConfigSchema   json.RawMessage    `json:"configSchema,omitempty"`
+	Chart          *ModuleChart       `json:"chart,omitempty"`
 }
 
+// ModuleService serves the module catalog. The catalog doesn't change after NewModuleService,
+// so it's safe to read concurrently; responses are copies so callers can't change it.
 type ModuleService struct {
-	modules []Module
-	github  *GitHubService
+	modules       []Module
+	configSchemas map[string]*jsonschema.Schema
+	github        *GitHubService
+	solutions     *SolutionService
+	search        *searchIndex
+	readmeOnce    sync.Once
 }
 
 func (m Module) ToResponse() ModuleResponse {
+	m.Attributes.Packages = maps.Clone(m.Attributes.Packages)
+	if m.Chart != nil {
+		chart := *m.Chart
+		m.Chart = &chart
+	}
 	return ModuleResponse{
 		ID:             m.ID,
 		Name:           m.Name,
 		Description:    m.Description,
 		Organization:   m.Organization,
 		LastUpdated:    m.LastUpdated,
-		Tags:           m.Tags,
+		Tags:           slices.Clone(m.Tags),
 		Version:        m.Version,
 		Maintainer:     m.Maintainer,
-		Dependencies:   m.Dependencies,
+		Dependencies:   slices.Clone(m.Dependencies),
 		Attributes:     m.Attributes,
-		Components:     m.Components,
+		Components:     slices.Clone(m.Components),
+		ConfigSchema:   slices.Clone(m.ConfigSchema),
+		Chart:          m.Chart,
 	}
 }
 
-func NewModuleService() *ModuleService {
+func NewModuleService(solutions *SolutionService) *ModuleService {
 	// Initialize with some mock data
 	modules := []Module{
 		{
@@ -139,6 +164,17 @@ func NewModuleService() *ModuleService {
 					Description: "Core decision engine for processing business rules",
 				},
 			},
+			ConfigSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"replicas": {"type": "integer", "minimum": 1, "default": 1, "description": "Number of decision engine replicas"},
+					"rulesRepository": {"type": "string", "format": "uri", "description": "Git repository the business rules are loaded from"},
+					"caseRetentionDays": {"type": "integer", "minimum": 1, "default": 90}
+				},
+				"required": ["rulesRepository"],
+				"additionalProperties": false
+			}`),
+			Chart: &ModuleChart{Path: "charts/blocc-module"},
 		},
 		{
 			ID:             "control-panel",
@@ -169,6 +205,15 @@ func NewModuleService() *ModuleService {
 					Description: "User interface for managing rules and configurations",
 				},
 			},
+			ConfigSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"theme": {"type": "string", "enum": ["light", "dark"], "default": "light"},
+					"auditLog": {"type": "boolean", "default": true}
+				},
+				"additionalProperties": false
+			}`),
+			Chart: &ModuleChart{Path: "charts/blocc-module"},
 		},
 		{
 			ID:             "flow",
@@ -201,12 +246,37 @@ func NewModuleService() *ModuleService {
 				},
 				
 			},
+			ConfigSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"replicas": {"type": "integer", "minimum": 1, "default": 1},
+					"historyLevel": {"type": "string", "enum": ["none", "activity", "audit", "full"], "default": "audit"},
+					"databaseUrl": {"type": "string", "writeOnly": true, "description": "JDBC URL of the Camunda database, including credentials"}
+				},
+				"additionalProperties": true
+			}`),
+			Chart: &ModuleChart{Repository: "oci://registry.stacc.dev/charts", Name: "flow", Version: "15.4.0"},
 		},
 	}
 
+	// Problems in the catalog are reported rather than fatal, the app still works with the valid parts
+	for _, module := range validateCatalog(modules).Modules {
+		for _, diagnostic := range module.Diagnostics {
+			log.Printf("catalog %s: module %q %s: %s", diagnostic.Severity, module.ModuleID, diagnostic.Field, diagnostic.Message)
+		}
+	}
+
+	search := newSearchIndex()
+	for _, module := range modules {
+		search.Index(module.ID, moduleSearchFields(module))
+	}
+
 	return &ModuleService{
-		modules: modules,
-		github:  NewGitHubService(),
+		modules:       modules,
+		configSchemas: compileConfigSchemas(modules),
+		github:        NewGitHubService(),
+		solutions:     solutions,
+		search:        search,
 	}
 }
 
@@ -219,58 +289,117 @@ func (s *ModuleService) GetModules() []ModuleResponse {
 }
 
 func (s *ModuleService) GetModule(id string) *ModuleResponse {
-	for _, module := range s.modules {
-		if module.ID == id {
-			response := module.ToResponse()
-			return &response
+	module := s.findModule(id)
+	if module == nil {
+		return nil
+	}
+	response := module.ToResponse()
+	return &response
+}
+
+// findModule returns the catalog entry for id, or nil if there is none
+func (s *ModuleService) findModule(id string) *Module {
+	for i := range s.modules {
+		if s.modules[i].ID == id {
+			return &s.modules[i]
 		}
 	}
 	return nil
 }
 
+// SearchModules returns the modules matching query, best match first
 func (s *ModuleService) SearchModules(query string) []ModuleResponse {
-	if query == "" {
+	if strings.TrimSpace(query) == "" {
 		return s.GetModules()
 	}
 
-	var results []Module
-	queryLower := strings.ToLower(query)
+	matches := s.SearchModuleMatches(query)
+	responses := make([]ModuleResponse, len(matches))
+	for i, match := range matches {
+		responses[i] = match.Module
+	}
+	return responses
+}
 
-	for _, module := range s.modules {
-		// Search in name, description, and tags
-		if strings.Contains(strings.ToLower(module.Name), queryLower) ||
-			strings.Contains(strings.ToLower(module.Description), queryLower) {
-			results = append(results, module)
+// SearchModuleMatches searches name, description, tags, component names, maintainer and
+// README text, tolerating typos. Results are ranked by relevance and include highlighted
+// snippets of the fields that matched.
+func (s *ModuleService) SearchModuleMatches(query string) []ModuleSearchResult {
+	// Unauthenticated requests are too scarce to spend on indexing, READMEs are then only
+	// indexed once they've been viewed
+	s.readmeOnce.Do(func() {
+		if s.github.authenticated {
+			go s.indexReadmes()
+		}
+	})
+
+	results := []ModuleSearchResult{}
+	for _, hit := range s.search.Search(query) {
+		module := s.GetModule(hit.id)
+		if module == nil {
 			continue
 		}
+		results = append(results, ModuleSearchResult{
+			Module:     *module,
+			Score:      hit.score,
+			Highlights: s.search.Highlights(hit.id, hit.terms),
+		})
+	}
+	return results
+}
 
-		// Search in tags
-		for _, tag := range module.Tags {
-			if strings.Contains(strings.ToLower(tag), queryLower) {
-				results = append(results, module)
-				break
-			}
+// indexReadmes adds the README text of every module with a GitHub repository to the search
+// index. It stops once GitHub's rate limit is hit, leaving the rest for GetModuleReadme.
+func (s *ModuleService) indexReadmes() {
+	for _, module := range s.modules {
+		if module.Attributes.GithubRepo == "" {
+			continue
 		}
+		content, err := s.github.GetReadmeMarkdown(module.Attributes.GithubRepo)
+		if errors.Is(err, ErrRateLimited) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+		s.indexReadme(module.ID, content)
 	}
+}
 
-	responses := make([]ModuleResponse, len(results))
-	for i, module := range results {
-		responses[i] = module.ToResponse()
+func (s *ModuleService) indexReadme(id string, content string) {
+	text, err := s.github.md.PlainText([]byte(content))
+	if err != nil {
+		return
 	}
-	return responses
+	s.search.Index(id, map[SearchField]string{SearchFieldReadme: text})
+}
+
+// ModuleReadme is the rendered README of a module, or the reason it couldn't be loaded
+type ModuleReadme struct {
+	HTML  string       `json:"html"`
+	Error *LookupError `json:"error,omitempty"`
 }
 
 // GetModuleReadme fetches the README content from GitHub if available
-func (s *ModuleService) GetModuleReadme(id string) string {
+func (s *ModuleService) GetModuleReadme(id string) ModuleReadme {
 	module := s.GetModule(id)
-	if module == nil || module.Attributes.GithubRepo == "" {
-		return ""
+	if module == nil {
+		return ModuleReadme{Error: newLookupError(ErrorKindNotFound, nil, "module %q not found", id)}
+	}
+	if module.Attributes.GithubRepo == "" {
+		return ModuleReadme{Error: newLookupError(ErrorKindNotFound, nil, "module %q has no GitHub repository", id)}
 	}
 
-	readme, err := s.github.GetReadmeFromURL(module.Attributes.GithubRepo)
+	content, err := s.github.GetReadmeMarkdown(module.Attributes.GithubRepo)
 	if err != nil {
-		return ""
+		return ModuleReadme{Error: asLookupError(err)}
 	}
+	s.indexReadme(id, content)
 
-	return readme
-} 
\ No newline at end of file
+	readme, err := s.github.md.Render([]byte(content))
+	if err != nil {
+		return ModuleReadme{Error: asLookupError(err)}
+	}
+
+	return ModuleReadme{HTML: readme}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// dnsLabelPattern is an RFC 1123 label, the format Kubernetes requires for namespaces
+var dnsLabelPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// reservedNamespaces belong to Kubernetes itself and can't hold an environment
+var reservedNamespaces = []string{"default", "kube-system", "kube-public", "kube-node-lease"}
+
+// FieldError is a validation error for one field of a request, so the frontend can show it
+// next to the input it belongs to
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// AddEnvironmentResult tells the frontend whether an environment was added and if not, why
+type AddEnvironmentResult struct {
+	Added       bool         `json:"added"`
+	Environment Environment  `json:"environment"`
+	Errors      []FieldError `json:"errors"`
+}
+
+// validateNamespace checks that a namespace is a valid RFC 1123 label that isn't reserved
+func validateNamespace(namespace string) error {
+	switch {
+	case len(namespace) > maxSlugLength:
+		return fmt.Errorf("namespace can be at most %d characters", maxSlugLength)
+	case !dnsLabelPattern.MatchString(namespace):
+		return fmt.Errorf("namespace must consist of lowercase letters, digits and '-', and start and end with a letter or digit")
+	case strings.HasPrefix(namespace, "kube-"):
+		return fmt.Errorf("namespaces starting with kube- are reserved")
+	}
+	for _, reserved := range reservedNamespaces {
+		if namespace == reserved {
+			return fmt.Errorf("namespace %s is reserved", namespace)
+		}
+	}
+	return nil
+}
+
+// namespaceOwner returns the solution and environment using a namespace, skipping the
+// environment exceptID of solution exceptSolutionID. Namespaces must be unique across
+// all solutions since they share a cluster.
+func (s *SolutionService) namespaceOwner(namespace string, exceptSolutionID string, exceptID string) (*Solution, *Environment) {
+	for i := range s.solutions {
+		solution := &s.solutions[i]
+		for j := range solution.Environments {
+			env := &solution.Environments[j]
+			if solution.ID == exceptSolutionID && env.ID == exceptID {
+				continue
+			}
+			if env.Namespace == namespace {
+				return solution, env
+			}
+		}
+	}
+	return nil, nil
+}
+
+// validateEnvironmentRequest checks the name and namespace of an environment being added
+// to or updated in a solution. exceptID is the environment being updated.
+func (s *SolutionService) validateEnvironmentRequest(solution *Solution, name string, namespace string, exceptID string) []FieldError {
+	var errors []FieldError
+	switch {
+	case name == "":
+		errors = append(errors, FieldError{Field: "name", Message: "environment name is required"})
+	case slugify(name) == "":
+		errors = append(errors, FieldError{Field: "name", Message: "environment name must contain at least one letter or digit"})
+	default:
+		for _, other := range solution.Environments {
+			if other.ID != exceptID && strings.EqualFold(other.Name, name) {
+				errors = append(errors, FieldError{Field: "name", Message: fmt.Sprintf("an environment named %q already exists", other.Name)})
+				break
+			}
+		}
+	}
+
+	if namespace == "" {
+		errors = append(errors, FieldError{Field: "namespace", Message: "namespace is required"})
+	} else if err := validateNamespace(namespace); err != nil {
+		errors = append(errors, FieldError{Field: "namespace", Message: err.Error()})
+	} else if owner, env := s.namespaceOwner(namespace, solution.ID, exceptID); env != nil {
+		errors = append(errors, FieldError{
+			Field:   "namespace",
+			Message: fmt.Sprintf("namespace %s is already used by %s in %s", namespace, env.Name, owner.Name),
+		})
+	}
+	return errors
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+type OperationStatus string
+
+const (
+	OperationStatusPending   OperationStatus = "pending"
+	OperationStatusRunning   OperationStatus = "running"
+	OperationStatusSucceeded OperationStatus = "succeeded"
+	OperationStatusFailed    OperationStatus = "failed"
+	OperationStatusCancelled OperationStatus = "cancelled"
+)
+
+// operationEvent is emitted with the Operation whenever an operation changes
+const operationEvent = "operation:updated"
+
+// maxStoredOperations is how many finished operations are kept once the app restarts
+const maxStoredOperations = 100
+
+type OperationStep struct {
+	Name       string          `json:"name"`
+	Status     OperationStatus `json:"status"`
+	Error      string          `json:"error"`
+	StartedAt  time.Time       `json:"startedAt" ts_type:"string"`
+	FinishedAt time.Time       `json:"finishedAt" ts_type:"string"`
+}
+
+// Operation is a change that runs in the background, such as deploying to an environment.
+// Progress is the percentage of steps that are done.
+type Operation struct {
+	ID            string          `json:"id"`
+	Kind          string          `json:"kind"`
+	Title         string          `json:"title"`
+	SolutionID    string          `json:"solutionId"`
+	EnvironmentID string          `json:"environmentId"`
+	Status        OperationStatus `json:"status"`
+	Progress      int             `json:"progress"`
+	Steps         []OperationStep `json:"steps"`
+	Error         string          `json:"error"`
+	CreatedAt     time.Time       `json:"createdAt" ts_type:"string"`
+	UpdatedAt     time.Time       `json:"updatedAt" ts_type:"string"`
+}
+
+func (op Operation) finished() bool {
+	return op.Status == OperationStatusSucceeded || op.Status == OperationStatusFailed || op.Status == OperationStatusCancelled
+}
+
+func (op Operation) clone() Operation {
+	op.Steps = append([]OperationStep{}, op.Steps...)
+	return op
+}
+
+// OperationService runs operations in the background and keeps track of them, including
+// those of earlier runs of the app. Every change is emitted as an operationEvent.
+type OperationService struct {
+	store *fileStore
+	// emit sends events to the frontend, main wires it to the application
+	emit       func(name string, data ...any)
+	mu         sync.Mutex
+	operations map[string]*Operation
+	cancels    map[string]context.CancelFunc
+}
+
+func operationsPath() []string {
+	return []string{"operations.json"}
+}
+
+func NewOperationService() *OperationService {
+	o := &OperationService{
+		store:      newFileStore(defaultDataDir()),
+		emit:       func(string, ...any) {},
+		operations: make(map[string]*Operation),
+		cancels:    make(map[string]context.CancelFunc),
+	}
+	if err := o.load(); err != nil {
+		log.Printf("failed to load operations: %v", err)
+	}
+	return o
+}
+
+// load reads the operations of earlier runs. Anything that was still running when the app
+// was closed didn't finish and is marked as failed.
+func (o *OperationService) load() error {
+	data, ok, err := o.store.Read(operationsPath()...)
+	if err != nil || !ok {
+		return err
+	}
+	var operations []Operation
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return err
+	}
+	for i := range operations {
+		op := &operations[i]
+		if !op.finished() {
+			op.Status = OperationStatusFailed
+			op.Error = "interrupted, the app was closed while the operation was running"
+			for j := range op.Steps {
+				if op.Steps[j].Status == OperationStatusRunning {
+					op.Steps[j].Status = OperationStatusFailed
+				}
+			}
+		}
+		o.operations[op.ID] = op
+	}
+	return nil
+}
+
+// save stores the operations, dropping the oldest finished ones. o.mu must be held.
+func (o *OperationService) save() {
+	operations := o.sorted("")
+	for len(operations) > maxStoredOperations && operations[len(operations)-1].finished() {
+		operations = operations[:len(operations)-1]
+	}
+	data, err := json.MarshalIndent(operations, "", "  ")
+	if err == nil {
+		err = o.store.Write(data, operationsPath()...)
+	}
+	if err != nil {
+		log.Printf("failed to save operations: %v", err)
+	}
+}
+
+// sorted returns copies of the operations of a solution, or all of them, newest first.
+// o.mu must be held.
+func (o *OperationService) sorted(solutionId string) []Operation {
+	operations := []Operation{}
+	for _, op := range o.operations {
+		if solutionId == "" || op.SolutionID == solutionId {
+			operations = append(operations, op.clone())
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].CreatedAt.After(operations[j].CreatedAt)
+	})
+	return operations
+}
+
+// update changes an operation, stores it and tells the frontend
+func (o *OperationService) update(id string, change func(op *Operation)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op, ok := o.operations[id]
+	if !ok {
+		return
+	}
+	change(op)
+	op.UpdatedAt = time.Now()
+	done := 0
+	for _, step := range op.Steps {
+		if step.Status == OperationStatusSucceeded {
+			done++
+		}
+	}
+	if len(op.Steps) > 0 {
+		op.Progress = done * 100 / len(op.Steps)
+	}
+	if op.Status == OperationStatusSucceeded {
+		op.Progress = 100
+	}
+	o.save()
+	o.emit(operationEvent, op.clone())
+}
+
+// operationRun is handed to the work of an operation to report its progress
+type operationRun struct {
+	service *OperationService
+	id      string
+}
+
+// finishStep ends the running step, if any, with status
+func finishStep(op *Operation, status OperationStatus, err error) {
+	for i := range op.Steps {
+		if op.Steps[i].Status == OperationStatusRunning {
+			op.Steps[i].Status = status
+			op.Steps[i].FinishedAt = time.Now()
+			if err != nil {
+				op.Steps[i].Error = err.Error()
+			}
+		}
+	}
+}
+
+// step finishes the running step and starts the one with the given name
+func (r *operationRun) step(name string) {
+	r.service.update(r.id, func(op *Operation) {
+		finishStep(op, OperationStatusSucceeded, nil)
+		for i := range op.Steps {
+			if op.Steps[i].Name == name {
+				op.Steps[i].Status = OperationStatusRunning
+				op.Steps[i].StartedAt = time.Now()
+				return
+			}
+		}
+		op.Steps = append(op.Steps, OperationStep{Name: name, Status: OperationStatusRunning, StartedAt: time.Now()})
+	})
+}
+
+// fail marks the running step as failed without ending the operation, for work that
+// carries on with its other steps
+func (r *operationRun) fail(err error) {
+	r.service.update(r.id, func(op *Operation) {
+		finishStep(op, OperationStatusFailed, err)
+	})
+}
+
+// start runs work in the background as a new operation with the given steps and returns
+// right away. work moves through the steps by calling run.step with their names and should
+// stop when ctx is cancelled.
+func (o *OperationService) start(op Operation, steps []string, work func(ctx context.Context, run *operationRun) error) Operation {
+	op.ID = newID()
+	op.Status = OperationStatusPending
+	op.CreatedAt = time.Now()
+	op.UpdatedAt = op.CreatedAt
+	op.Steps = make([]OperationStep, len(steps))
+	for i, name := range steps {
+		op.Steps[i] = OperationStep{Name: name, Status: OperationStatusPending}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	o.mu.Lock()
+	o.operations[op.ID] = &op
+	o.cancels[op.ID] = cancel
+	started := op.clone()
+	o.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		o.update(op.ID, func(op *Operation) {
+			op.Status = OperationStatusRunning
+		})
+		err := work(ctx, &operationRun{service: o, id: op.ID})
+		o.update(op.ID, func(op *Operation) {
+			switch {
+			case err == nil:
+				finishStep(op, OperationStatusSucceeded, nil)
+				op.Status = OperationStatusSucceeded
+			case errors.Is(err, context.Canceled):
+				finishStep(op, OperationStatusCancelled, nil)
+				op.Status = OperationStatusCancelled
+				op.Error = "cancelled"
+			default:
+				finishStep(op, OperationStatusFailed, err)
+				op.Status = OperationStatusFailed
+				op.Error = err.Error()
+			}
+		})
+		o.mu.Lock()
+		delete(o.cancels, op.ID)
+		o.mu.Unlock()
+	}()
+	return started
+}
+
+// GetOperation returns an operation of this or an earlier run of the app
+func (o *OperationService) GetOperation(id string) (Operation, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op, ok := o.operations[id]
+	if !ok {
+		return Operation{}, fmt.Errorf("operation not found")
+	}
+	return op.clone(), nil
+}
+
+// ListOperations returns the operations of a solution, or of all solutions if solutionId
+// is empty, newest first
+func (o *OperationService) ListOperations(solutionId string) []Operation {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.sorted(solutionId)
+}
+
+// CancelOperation asks a running operation to stop. It ends as cancelled once the step
+// it's in gives up.
+func (o *OperationService) CancelOperation(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op, ok := o.operations[id]
+	if !ok {
+		return fmt.Errorf("operation not found")
+	}
+	cancel, ok := o.cancels[id]
+	if !ok || op.finished() {
+		return fmt.Errorf("operation has already finished")
+	}
+	cancel()
+	return nil
+}
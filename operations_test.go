@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestOperationIDsUnique starts operations faster than the clock ticks, none of them may
+// replace another
+func TestOperationIDsUnique(t *testing.T) {
+	t.Setenv(dataDirEnv, t.TempDir())
+	o := NewOperationService()
+
+	const started = 200
+	release := make(chan struct{})
+	ids := make(map[string]bool, started)
+	for range started {
+		op := o.start(Operation{Kind: "test", Title: "Test"}, nil, func(ctx context.Context, run *operationRun) error {
+			<-release
+			return nil
+		})
+		if ids[op.ID] {
+			t.Fatalf("operation ID %q is used twice", op.ID)
+		}
+		ids[op.ID] = true
+	}
+	if operations := o.ListOperations(""); len(operations) != started {
+		t.Errorf("listed %d operations, want %d", len(operations), started)
+	}
+
+	close(release)
+	for _, op := range o.ListOperations("") {
+		for !op.finished() {
+			time.Sleep(time.Millisecond)
+			op, _ = o.GetOperation(op.ID)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvironmentStage is where an environment sits in the path from development to production
+type EnvironmentStage string
+
+const (
+	EnvironmentStageDevelopment EnvironmentStage = "development"
+	EnvironmentStageStaging     EnvironmentStage = "staging"
+	EnvironmentStageProduction  EnvironmentStage = "production"
+)
+
+var stageOrder = map[EnvironmentStage]int{
+	EnvironmentStageDevelopment: 0,
+	EnvironmentStageStaging:     1,
+	EnvironmentStageProduction:  2,
+}
+
+// environmentStage derives the stage of an environment from its name and namespace.
+// Anything that is neither development nor production counts as staging.
+func environmentStage(env Environment) EnvironmentStage {
+	if isDevelopmentEnvironment(env) {
+		return EnvironmentStageDevelopment
+	}
+	if strings.Contains(strings.ToLower(env.Name), "prod") || strings.Contains(strings.ToLower(env.Namespace), "prod") {
+		return EnvironmentStageProduction
+	}
+	return EnvironmentStageStaging
+}
+
+type PromotionAction string
+
+const (
+	PromotionActionInstall   PromotionAction = "install"
+	PromotionActionUpgrade   PromotionAction = "upgrade"
+	PromotionActionDowngrade PromotionAction = "downgrade"
+	PromotionActionUninstall PromotionAction = "uninstall"
+	PromotionActionUnchanged PromotionAction = "unchanged"
+)
+
+// ModulePromotion is what happens to a single module in the target environment.
+// ConfigChanges are the changes to the module's values in the target's configuration.
+type ModulePromotion struct {
+	ModuleID      string          `json:"moduleId"`
+	Action        PromotionAction `json:"action"`
+	FromVersion   string          `json:"fromVersion"`
+	ToVersion     string          `json:"toVersion"`
+	ConfigChanges []ConfigChange  `json:"configChanges"`
+}
+
+// PromotionPlan describes what promoting modules from one environment to another would
+// change. A plan with violations is blocked and can't be applied.
+type PromotionPlan struct {
+	SolutionID        string            `json:"solutionId"`
+	FromEnvironmentID string            `json:"fromEnvironmentId"`
+	ToEnvironmentID   string            `json:"toEnvironmentId"`
+	Modules           []ModulePromotion `json:"modules"`
+	Violations        []string          `json:"violations"`
+	Blocked           bool              `json:"blocked"`
+}
+
+// PromotionRecord is a promotion that was applied
+type PromotionRecord struct {
+	ID                string            `json:"id"`
+	FromEnvironmentID string            `json:"fromEnvironmentId"`
+	ToEnvironmentID   string            `json:"toEnvironmentId"`
+	Modules           []ModulePromotion `json:"modules"`
+	ConfigRevision    int               `json:"configRevision"`
+	Author            string            `json:"author"`
+	PromotedAt        time.Time         `json:"promotedAt" ts_type:"string"`
+}
+
+func promotionHistoryPath(solutionID string) []string {
+	return []string{"solutions", solutionID, "promotions.json"}
+}
+
+// compareVersions compares two dotted version numbers such as 1.2.10 and v1.3, returning
+// -1, 0 or 1. Pre-release and build suffixes are ignored.
+func compareVersions(a string, b string) int {
+	parts := func(v string) []int {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+		if i := strings.IndexAny(v, "-+"); i >= 0 {
+			v = v[:i]
+		}
+		var numbers []int
+		for _, part := range strings.Split(v, ".") {
+			n, _ := strconv.Atoi(part)
+			numbers = append(numbers, n)
+		}
+		return numbers
+	}
+	pa, pb := parts(a), parts(b)
+	for i := 0; i < max(len(pa), len(pb)); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// promotedValues returns the values a module gets in the target environment: the source's
+// values, except for secret references, which are specific to an environment and are
+// kept as they are in the target
+func promotedValues(source map[string]any, target map[string]any) map[string]any {
+	values := make(map[string]any, len(source))
+	for key, value := range source {
+		if _, isRef, _ := asSecretRef(value); !isRef {
+			values[key] = value
+		}
+	}
+	for key, value := range target {
+		if _, isRef, _ := asSecretRef(value); isRef {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// promotion works out the plan for promoting moduleIds from one environment to another,
+// along with the configuration document the target would get
+func (s *SolutionService) promotion(solutionId string, fromEnvironmentId string, toEnvironmentId string, moduleIds []string) (PromotionPlan, *yaml.Node, []EnvironmentModule, error) {
+	_, from, err := s.findEnvironment(solutionId, fromEnvironmentId)
+	if err != nil {
+		return PromotionPlan{}, nil, nil, err
+	}
+	_, to, err := s.findEnvironment(solutionId, toEnvironmentId)
+	if err != nil {
+		return PromotionPlan{}, nil, nil, err
+	}
+	if from.ID == to.ID {
+		return PromotionPlan{}, nil, nil, fmt.Errorf("can't promote an environment to itself")
+	}
+
+	plan := PromotionPlan{
+		SolutionID:        solutionId,
+		FromEnvironmentID: from.ID,
+		ToEnvironmentID:   to.ID,
+		Modules:           []ModulePromotion{},
+		Violations:        []string{},
+	}
+	violate := func(format string, args ...any) {
+		plan.Violations = append(plan.Violations, fmt.Sprintf(format, args...))
+	}
+
+	fromStage, toStage := environmentStage(*from), environmentStage(*to)
+	switch {
+	case stageOrder[toStage] < stageOrder[fromStage]:
+		violate("%s is a %s environment, promotions go from development towards production, not back from %s", to.Name, toStage, fromStage)
+	case toStage == EnvironmentStageProduction && fromStage != EnvironmentStageStaging:
+		violate("%s is a production environment and only accepts promotions from a staging environment", to.Name)
+	}
+
+	if len(moduleIds) == 0 {
+		for _, module := range from.Modules {
+			moduleIds = append(moduleIds, module.ModuleID)
+		}
+	}
+
+	fromContent, err := s.currentConfigContent(solutionId, *from)
+	if err != nil {
+		return PromotionPlan{}, nil, nil, err
+	}
+	toContent, err := s.currentConfigContent(solutionId, *to)
+	if err != nil {
+		return PromotionPlan{}, nil, nil, err
+	}
+	_, fromDoc, _ := parseEnvironmentConfig(fromContent, *from)
+	var toNode yaml.Node
+	if err := yaml.Unmarshal([]byte(toContent), &toNode); err != nil || len(toNode.Content) == 0 {
+		return PromotionPlan{}, nil, nil, fmt.Errorf("configuration of %s can't be read, fix it before promoting to it", to.Name)
+	}
+	_, toDoc, _ := parseEnvironmentConfig(toContent, *to)
+
+	modules := append([]EnvironmentModule{}, to.Modules...)
+	for _, moduleID := range moduleIds {
+		var source *EnvironmentModule
+		for i := range from.Modules {
+			if from.Modules[i].ModuleID == moduleID {
+				source = &from.Modules[i]
+			}
+		}
+		if source == nil {
+			violate("module %q is not installed in %s", moduleID, from.Name)
+			continue
+		}
+
+		promotion := ModulePromotion{ModuleID: moduleID, Action: PromotionActionInstall, ToVersion: source.Version}
+		target := -1
+		for i := range modules {
+			if modules[i].ModuleID == moduleID {
+				target = i
+			}
+		}
+		if target >= 0 {
+			promotion.FromVersion = modules[target].Version
+			switch compareVersions(source.Version, modules[target].Version) {
+			case 1:
+				promotion.Action = PromotionActionUpgrade
+			case -1:
+				promotion.Action = PromotionActionDowngrade
+				violate("promoting %q would downgrade it in %s from %s to %s", moduleID, to.Name, modules[target].Version, source.Version)
+			default:
+				promotion.Action = PromotionActionUnchanged
+			}
+			modules[target].Version = source.Version
+		} else {
+			modules = append(modules, EnvironmentModule{ModuleID: moduleID, Version: source.Version, Status: EnvironmentStatusStopped})
+		}
+
+		sourceValues := fromDoc.Spec.Modules[moduleID].Values
+		targetValues := toDoc.Spec.Modules[moduleID].Values
+		values := promotedValues(sourceValues, targetValues)
+		promotion.ConfigChanges = diffValues("spec.modules."+moduleID+".values", targetValues, values)
+		if len(promotion.ConfigChanges) > 0 || target < 0 {
+			var valuesNode yaml.Node
+			if err := valuesNode.Encode(values); err != nil {
+				return PromotionPlan{}, nil, nil, err
+			}
+			setMappingNode(toNode.Content[0], &valuesNode, "spec", "modules", moduleID, "values")
+		}
+		plan.Modules = append(plan.Modules, promotion)
+	}
+
+	// The target has to end up with a valid configuration, e.g. new modules may need
+	// values or secrets that only exist for the source
+	promoted := *to
+	promoted.Modules = modules
+	content, err := encodeYAML(&toNode)
+	if err != nil {
+		return PromotionPlan{}, nil, nil, err
+	}
+	doc, parsed, issues := parseEnvironmentConfig(content, promoted)
+	if len(issues) == 0 {
+		solutionDoc, err := s.loadSolutionDocument(solutionId)
+		if err != nil {
+			return PromotionPlan{}, nil, nil, err
+		}
+		issues = s.validateEffectiveConfig(s.effectiveConfig(solutionId, promoted, solutionDoc, parsed), doc.Content[0], "")
+	}
+	for _, issue := range issues {
+		violate("configuration of %s would be invalid: %s", to.Name, issue.Message)
+	}
+
+	plan.Blocked = len(plan.Violations) > 0
+	return plan, doc, modules, nil
+}
+
+// diffValues compares the values of a module, paths are below prefix
+func diffValues(prefix string, before map[string]any, after map[string]any) []ConfigChange {
+	wrap := func(values map[string]any) string {
+		data, _ := yaml.Marshal(map[string]any{"spec": map[string]any{"values": values}})
+		return string(data)
+	}
+	changes, err := diffConfigDocuments(wrap(before), wrap(after))
+	if err != nil {
+		return []ConfigChange{}
+	}
+	for i := range changes {
+		changes[i].Path = prefix + strings.TrimPrefix(changes[i].Path, "spec.values")
+	}
+	return changes
+}
+
+// PlanPromotion shows what promoting modules from one environment to another would change
+// without applying anything. With no moduleIds all modules of the source are promoted.
+func (s *SolutionService) PlanPromotion(solutionId string, fromEnvironmentId string, toEnvironmentId string, moduleIds []string) (PromotionPlan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	plan, _, _, err := s.promotion(solutionId, fromEnvironmentId, toEnvironmentId, moduleIds)
+	return plan, err
+}
+
+// PromoteEnvironment moves the versions and configuration of modules from one environment
+// to another, see PlanPromotion, and deploys them to the target. Nothing is changed if the
+// plan violates a policy of the target environment. The promotion is recorded in the
+// solution's promotion history and the target's configuration history. The deployment
+// runs in the background, the returned operation ID tracks it.
+func (s *SolutionService) PromoteEnvironment(solutionId string, fromEnvironmentId string, toEnvironmentId string, moduleIds []string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan, doc, modules, err := s.promotion(solutionId, fromEnvironmentId, toEnvironmentId, moduleIds)
+	if err != nil {
+		return "", err
+	}
+	if plan.Blocked {
+		return "", fmt.Errorf("promotion is blocked: %s", strings.Join(plan.Violations, "; "))
+	}
+
+	_, from, _ := s.findEnvironment(solutionId, fromEnvironmentId)
+	_, to, _ := s.findEnvironment(solutionId, toEnvironmentId)
+	if isTransitional(to.Status) {
+		return "", fmt.Errorf("%s is %s, wait for it to finish", to.Name, to.Status)
+	}
+
+	content, err := encodeYAML(doc)
+	if err != nil {
+		return "", err
+	}
+	message := fmt.Sprintf("Promoted from %s", from.Name)
+	result, err := s.applyDeployment(solutionId, to, modules, content, ConfigRevision{Message: message}, DeploymentEventPromotion, message)
+	if err != nil {
+		return "", err
+	}
+	if !result.Saved {
+		return "", fmt.Errorf("configuration of %s is invalid: %s", to.Name, result.Issues[0].Message)
+	}
+
+	record := PromotionRecord{
+		ID:                newID(),
+		FromEnvironmentID: from.ID,
+		ToEnvironmentID:   to.ID,
+		Modules:           plan.Modules,
+		ConfigRevision:    result.Config.Revision,
+		Author:            currentAuthor(),
+		PromotedAt:        time.Now(),
+	}
+	if err := s.recordPromotion(solutionId, record); err != nil {
+		return "", err
+	}
+	return s.rollOut(solutionId, to, nil, "promotion", fmt.Sprintf("Promote %s to %s", from.Name, to.Name))
+}
+
+func (s *SolutionService) loadPromotionHistory(solutionId string) ([]PromotionRecord, error) {
+	records := []PromotionRecord{}
+	data, ok, err := s.store.Read(promotionHistoryPath(solutionId)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read promotion history: %w", err)
+	}
+	if !ok {
+		return records, nil
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to read promotion history: %w", err)
+	}
+	return records, nil
+}
+
+func (s *SolutionService) recordPromotion(solutionId string, record PromotionRecord) error {
+	records, err := s.loadPromotionHistory(solutionId)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(append(records, record), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := s.store.Write(data, promotionHistoryPath(solutionId)...); err != nil {
+		return fmt.Errorf("failed to save promotion history: %w", err)
+	}
+	return nil
+}
+
+// GetPromotionHistory returns the promotions applied to the environments of a solution, newest first
+func (s *SolutionService) GetPromotionHistory(solutionId string) ([]PromotionRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.findSolution(solutionId) == nil {
+		return nil, fmt.Errorf("solution not found")
+	}
+	records, err := s.loadPromotionHistory(solutionId)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].PromotedAt.After(records[j].PromotedAt)
+	})
+	return records, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// repoURL is a parsed git repository URL. Ref and Subpath are only set for
+// browser URLs pointing into a repository, e.g. .../tree/main/packages/x
+type repoURL struct {
+	Host    string
+	Owner   string
+	Repo    string
+	Ref     string
+	Subpath string
+}
+
+// scpLikeURL matches the scp-like syntax used by git for SSH remotes, e.g. git@github.com:owner/repo.git
+var scpLikeURL = regexp.MustCompile(`^(?:[\w.\-]+@)?([\w.\-]+):([^/].*)$`)
+
+// parseRepoURL parses the repository URL formats found in module attributes:
+//
+//	https://github.com/owner/repo
+//	https://github.com/owner/repo.git
+//	https://github.com/owner/repo/tree/main/packages/x
+//	git@github.com:owner/repo.git
+//	ssh://git@github.com/owner/repo.git
+//	github.com/owner/repo
+//	owner/repo
+//
+// A URL without a host, such as owner/repo, is taken to be on GitHub.
+// Refs containing slashes can't be told apart from the subpath in tree URLs, so the
+// first segment after tree/ or blob/ is always taken as the ref.
+func parseRepoURL(raw string) (repoURL, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return repoURL{}, fmt.Errorf("repository URL is empty")
+	}
+
+	original := raw
+	var host, path string
+	if match := scpLikeURL.FindStringSubmatch(raw); match != nil && !strings.Contains(raw, "://") {
+		host, path = match[1], match[2]
+	} else {
+		if !strings.Contains(raw, "://") {
+			if first, _, _ := strings.Cut(raw, "/"); !strings.Contains(first, ".") {
+				raw = "github.com/" + raw
+			}
+			raw = "https://" + raw
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			return repoURL{}, fmt.Errorf("invalid repository URL %q: %w", original, err)
+		}
+		host, path = u.Hostname(), u.Path
+	}
+
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if host == "" || len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return repoURL{}, fmt.Errorf("repository URL %q must include an owner and repository", original)
+	}
+
+	parsed := repoURL{
+		Host:  host,
+		Owner: parts[0],
+		Repo:  strings.TrimSuffix(parts[1], ".git"),
+	}
+
+	rest := parts[2:]
+	if len(rest) >= 2 && (rest[0] == "tree" || rest[0] == "blob") {
+		parsed.Ref = rest[1]
+		subpath := rest[2:]
+		// A blob URL points at a file, the README lives in its directory
+		if rest[0] == "blob" && len(subpath) > 0 {
+			subpath = subpath[:len(subpath)-1]
+		}
+		parsed.Subpath = strings.Join(subpath, "/")
+	}
+
+	return parsed, nil
+}
+
+// IsGitHub reports whether the repository is hosted on github.com
+func (r repoURL) IsGitHub() bool {
+	return r.Host == "github.com"
+}
+
+func (r repoURL) String() string {
+	s := r.Owner + "/" + r.Repo
+	if r.Subpath != "" {
+		s += "/" + r.Subpath
+	}
+	return s
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestParseRepoURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want repoURL
+	}{
+		{"https://github.com/owner/repo", repoURL{Host: "github.com", Owner: "owner", Repo: "repo"}},
+		{"https://github.com/owner/repo.git", repoURL{Host: "github.com", Owner: "owner", Repo: "repo"}},
+		{"https://www.github.com/owner/repo/", repoURL{Host: "github.com", Owner: "owner", Repo: "repo"}},
+		{"https://github.com/owner/repo/tree/main/packages/x", repoURL{Host: "github.com", Owner: "owner", Repo: "repo", Ref: "main", Subpath: "packages/x"}},
+		{"https://github.com/owner/repo/blob/v1.2/packages/x/README.md", repoURL{Host: "github.com", Owner: "owner", Repo: "repo", Ref: "v1.2", Subpath: "packages/x"}},
+		{"git@github.com:owner/repo.git", repoURL{Host: "github.com", Owner: "owner", Repo: "repo"}},
+		{"ssh://git@github.com/owner/repo.git", repoURL{Host: "github.com", Owner: "owner", Repo: "repo"}},
+		{"github.com/owner/repo", repoURL{Host: "github.com", Owner: "owner", Repo: "repo"}},
+		{"owner/repo", repoURL{Host: "github.com", Owner: "owner", Repo: "repo"}},
+		{" stacc/foo.git ", repoURL{Host: "github.com", Owner: "stacc", Repo: "foo"}},
+		{"https://gitlab.example.com/group/project", repoURL{Host: "gitlab.example.com", Owner: "group", Repo: "project"}},
+	}
+	for _, tt := range tests {
+		got, err := parseRepoURL(tt.raw)
+		if err != nil {
+			t.Errorf("parseRepoURL(%q): %v", tt.raw, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseRepoURL(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+
+	for _, raw := range []string{"", "   ", "owner", "github.com/owner", "https://github.com/", "git@github.com:owner"} {
+		if got, err := parseRepoURL(raw); err == nil {
+			t.Errorf("parseRepoURL(%q) = %+v, want an error", raw, got)
+		}
+	}
+}
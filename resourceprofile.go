@@ -0,0 +1,356 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ResourceQuantities are CPU and memory amounts in Kubernetes notation, e.g. 500m and 512Mi
+type ResourceQuantities struct {
+	CPU    string `json:"cpu" yaml:"cpu,omitempty"`
+	Memory string `json:"memory" yaml:"memory,omitempty"`
+}
+
+// ComponentResources are the resources a component is deployed with
+type ComponentResources struct {
+	Replicas int                `json:"replicas"`
+	Requests ResourceQuantities `json:"requests"`
+	Limits   ResourceQuantities `json:"limits"`
+}
+
+// ResourceProfile is a named set of resources for each type of component
+type ResourceProfile struct {
+	Name        string                               `json:"name"`
+	Label       string                               `json:"label"`
+	Description string                               `json:"description"`
+	Components  map[ComponentType]ComponentResources `json:"components"`
+}
+
+// ComponentResourceOverride changes the resources of a single component. It's set in
+// configuration under spec.modules.<module>.resources.<component>, fields that are left
+// out come from the resource profile.
+type ComponentResourceOverride struct {
+	Replicas *int               `yaml:"replicas"`
+	Requests ResourceQuantities `yaml:"requests"`
+	Limits   ResourceQuantities `yaml:"limits"`
+}
+
+// ComponentResourcePlan is what a component is deployed with after applying the resource
+// profile and overrides
+type ComponentResourcePlan struct {
+	ModuleID      string             `json:"moduleId"`
+	ComponentID   string             `json:"componentId"`
+	ComponentName string             `json:"componentName"`
+	Type          ComponentType      `json:"type"`
+	Replicas      int                `json:"replicas"`
+	Requests      ResourceQuantities `json:"requests"`
+	Limits        ResourceQuantities `json:"limits"`
+	Overridden    bool               `json:"overridden"`
+}
+
+// ResourceIssue is a problem with the resources of an environment. Path is the dotted
+// path of the configuration key that causes it.
+type ResourceIssue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ResourcePlan is what all components of an environment are deployed with. Requests,
+// Limits and Pods are the totals over all replicas, Quota is the limits the environment
+// sets for itself in spec.quota.
+type ResourcePlan struct {
+	Profile    string                  `json:"profile"`
+	Components []ComponentResourcePlan `json:"components"`
+	Requests   ResourceQuantities      `json:"requests"`
+	Limits     ResourceQuantities      `json:"limits"`
+	Pods       int                     `json:"pods"`
+	Quota      map[string]string       `json:"quota"`
+	Issues     []ResourceIssue         `json:"issues"`
+}
+
+// quotaKeys are the ResourceQuota limits that are checked, see spec.quota
+var quotaKeys = []string{"requests.cpu", "requests.memory", "limits.cpu", "limits.memory", "pods"}
+
+// resourceProfiles are the resource profiles environments can choose from
+var resourceProfiles = []ResourceProfile{
+	{
+		Name:        "small",
+		Label:       "Small",
+		Description: "A single replica of everything with minimal resources, for development and testing",
+		Components: map[ComponentType]ComponentResources{
+			ComponentTypeBackend:    {Replicas: 1, Requests: ResourceQuantities{CPU: "250m", Memory: "256Mi"}, Limits: ResourceQuantities{CPU: "500m", Memory: "512Mi"}},
+			ComponentTypeFrontend:   {Replicas: 1, Requests: ResourceQuantities{CPU: "50m", Memory: "64Mi"}, Limits: ResourceQuantities{CPU: "100m", Memory: "128Mi"}},
+			ComponentTypeApiGateway: {Replicas: 1, Requests: ResourceQuantities{CPU: "100m", Memory: "128Mi"}, Limits: ResourceQuantities{CPU: "250m", Memory: "256Mi"}},
+			ComponentTypeSetup:      {Replicas: 1, Requests: ResourceQuantities{CPU: "100m", Memory: "128Mi"}, Limits: ResourceQuantities{CPU: "250m", Memory: "256Mi"}},
+		},
+	},
+	{
+		Name:        "medium",
+		Label:       "Medium",
+		Description: "Two replicas of each service, for staging and small production workloads",
+		Components: map[ComponentType]ComponentResources{
+			ComponentTypeBackend:    {Replicas: 2, Requests: ResourceQuantities{CPU: "500m", Memory: "512Mi"}, Limits: ResourceQuantities{CPU: "1", Memory: "1Gi"}},
+			ComponentTypeFrontend:   {Replicas: 2, Requests: ResourceQuantities{CPU: "100m", Memory: "128Mi"}, Limits: ResourceQuantities{CPU: "200m", Memory: "256Mi"}},
+			ComponentTypeApiGateway: {Replicas: 2, Requests: ResourceQuantities{CPU: "250m", Memory: "256Mi"}, Limits: ResourceQuantities{CPU: "500m", Memory: "512Mi"}},
+			ComponentTypeSetup:      {Replicas: 1, Requests: ResourceQuantities{CPU: "100m", Memory: "128Mi"}, Limits: ResourceQuantities{CPU: "500m", Memory: "512Mi"}},
+		},
+	},
+	{
+		Name:        "large",
+		Label:       "Large",
+		Description: "Three replicas of each service with room to spare, for production",
+		Components: map[ComponentType]ComponentResources{
+			ComponentTypeBackend:    {Replicas: 3, Requests: ResourceQuantities{CPU: "1", Memory: "1Gi"}, Limits: ResourceQuantities{CPU: "2", Memory: "2Gi"}},
+			ComponentTypeFrontend:   {Replicas: 3, Requests: ResourceQuantities{CPU: "100m", Memory: "128Mi"}, Limits: ResourceQuantities{CPU: "500m", Memory: "512Mi"}},
+			ComponentTypeApiGateway: {Replicas: 3, Requests: ResourceQuantities{CPU: "500m", Memory: "512Mi"}, Limits: ResourceQuantities{CPU: "1", Memory: "1Gi"}},
+			ComponentTypeSetup:      {Replicas: 1, Requests: ResourceQuantities{CPU: "250m", Memory: "256Mi"}, Limits: ResourceQuantities{CPU: "1", Memory: "1Gi"}},
+		},
+	},
+}
+
+// GetResourceProfiles returns the resource profiles environments can choose from
+func (s *SolutionService) GetResourceProfiles() []ResourceProfile {
+	return resourceProfiles
+}
+
+// findResourceProfile returns the resource profile with the given name, or nil
+func findResourceProfile(name string) *ResourceProfile {
+	for i := range resourceProfiles {
+		if resourceProfiles[i].Name == name {
+			return &resourceProfiles[i]
+		}
+	}
+	return nil
+}
+
+var quantityPattern = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)(m|k|M|G|T|P|E|Ki|Mi|Gi|Ti|Pi|Ei)?$`)
+
+var quantitySuffixes = map[string]float64{
+	"":   1,
+	"m":  1e-3,
+	"k":  1e3,
+	"M":  1e6,
+	"G":  1e9,
+	"T":  1e12,
+	"P":  1e15,
+	"E":  1e18,
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+	"Pi": 1 << 50,
+	"Ei": 1 << 60,
+}
+
+// parseQuantity parses a Kubernetes quantity such as 500m, 2, 512Mi or 1G into its value
+// in base units, i.e. cores for CPU and bytes for memory
+func parseQuantity(quantity string) (float64, error) {
+	match := quantityPattern.FindStringSubmatch(strings.TrimSpace(quantity))
+	if match == nil {
+		return 0, fmt.Errorf("%q is not a valid quantity, use e.g. 500m, 2, 512Mi or 1Gi", quantity)
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid quantity", quantity)
+	}
+	return value * quantitySuffixes[match[2]], nil
+}
+
+// formatCPU formats cores in Kubernetes notation, whole cores plain and anything else in millicores
+func formatCPU(cores float64) string {
+	millis := math.Round(cores * 1000)
+	if math.Mod(millis, 1000) == 0 {
+		return strconv.FormatFloat(millis/1000, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(millis, 'f', -1, 64) + "m"
+}
+
+// formatMemory formats bytes in Kubernetes notation using the largest binary unit that fits exactly
+func formatMemory(bytes float64) string {
+	for _, suffix := range []string{"Ti", "Gi", "Mi", "Ki"} {
+		unit := quantitySuffixes[suffix]
+		if bytes >= unit && math.Mod(bytes, unit) == 0 {
+			return strconv.FormatFloat(bytes/unit, 'f', -1, 64) + suffix
+		}
+	}
+	if bytes >= quantitySuffixes["Mi"] {
+		return strconv.FormatFloat(math.Ceil(bytes/quantitySuffixes["Mi"]), 'f', -1, 64) + "Mi"
+	}
+	return strconv.FormatFloat(bytes, 'f', -1, 64)
+}
+
+// applyOverride returns resources with the fields set in override replaced
+func applyOverride(resources ComponentResources, override ComponentResourceOverride) ComponentResources {
+	if override.Replicas != nil {
+		resources.Replicas = *override.Replicas
+	}
+	if override.Requests.CPU != "" {
+		resources.Requests.CPU = override.Requests.CPU
+	}
+	if override.Requests.Memory != "" {
+		resources.Requests.Memory = override.Requests.Memory
+	}
+	if override.Limits.CPU != "" {
+		resources.Limits.CPU = override.Limits.CPU
+	}
+	if override.Limits.Memory != "" {
+		resources.Limits.Memory = override.Limits.Memory
+	}
+	return resources
+}
+
+// resourcePlan works out the resources of every component of env from the resource profile
+// and the overrides in the solution's and the environment's configuration, and checks them
+// against the quota set in spec.quota
+func (s *SolutionService) resourcePlan(env Environment, profileName string, solutionDoc configDocument, envDoc configDocument) ResourcePlan {
+	plan := ResourcePlan{
+		Profile:    profileName,
+		Components: []ComponentResourcePlan{},
+		Quota:      make(map[string]string),
+		Issues:     []ResourceIssue{},
+	}
+	report := func(path string, format string, args ...any) {
+		plan.Issues = append(plan.Issues, ResourceIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	profile := findResourceProfile(profileName)
+	if profile == nil {
+		names := make([]string, len(resourceProfiles))
+		for i, p := range resourceProfiles {
+			names[i] = p.Name
+		}
+		report("spec.resourceProfile", "unknown resource profile %q, use one of %s", profileName, strings.Join(names, ", "))
+		return plan
+	}
+
+	var cpuRequests, memoryRequests, cpuLimits, memoryLimits float64
+	for _, installed := range env.Modules {
+		var module *Module
+		if s.modules != nil {
+			module = s.modules.findModule(installed.ModuleID)
+		}
+		if module == nil {
+			continue
+		}
+
+		overrides := make(map[string][]ComponentResourceOverride)
+		for _, doc := range []configDocument{solutionDoc, envDoc} {
+			for componentID, override := range doc.Spec.Modules[module.ID].Resources {
+				overrides[componentID] = append(overrides[componentID], override)
+			}
+		}
+		for _, componentID := range sortedKeys(overrides) {
+			if !hasComponent(*module, componentID) {
+				report("spec.modules."+module.ID+".resources."+componentID, "module %q has no component %q", module.ID, componentID)
+			}
+		}
+
+		for _, component := range module.Components {
+			resources := profile.Components[component.Type]
+			for _, override := range overrides[component.ID] {
+				resources = applyOverride(resources, override)
+			}
+			plan.Components = append(plan.Components, ComponentResourcePlan{
+				ModuleID:      module.ID,
+				ComponentID:   component.ID,
+				ComponentName: component.Name,
+				Type:          component.Type,
+				Replicas:      resources.Replicas,
+				Requests:      resources.Requests,
+				Limits:        resources.Limits,
+				Overridden:    len(overrides[component.ID]) > 0,
+			})
+
+			path := "spec.modules." + module.ID + ".resources." + component.ID
+			if resources.Replicas < 0 {
+				report(path+".replicas", "replicas of %q must not be negative", component.ID)
+			}
+			quantity := func(field string, value string) float64 {
+				if value == "" {
+					return 0
+				}
+				v, err := parseQuantity(value)
+				if err != nil {
+					report(path+"."+field, "%s", err.Error())
+				}
+				return v
+			}
+			requestCPU := quantity("requests.cpu", resources.Requests.CPU)
+			requestMemory := quantity("requests.memory", resources.Requests.Memory)
+			limitCPU := quantity("limits.cpu", resources.Limits.CPU)
+			limitMemory := quantity("limits.memory", resources.Limits.Memory)
+			if limitCPU > 0 && requestCPU > limitCPU {
+				report(path+".requests.cpu", "CPU request of %q (%s) exceeds its limit (%s)", component.ID, resources.Requests.CPU, resources.Limits.CPU)
+			}
+			if limitMemory > 0 && requestMemory > limitMemory {
+				report(path+".requests.memory", "memory request of %q (%s) exceeds its limit (%s)", component.ID, resources.Requests.Memory, resources.Limits.Memory)
+			}
+
+			replicas := float64(max(resources.Replicas, 0))
+			cpuRequests += replicas * requestCPU
+			memoryRequests += replicas * requestMemory
+			cpuLimits += replicas * limitCPU
+			memoryLimits += replicas * limitMemory
+			plan.Pods += max(resources.Replicas, 0)
+		}
+	}
+
+	plan.Requests = ResourceQuantities{CPU: formatCPU(cpuRequests), Memory: formatMemory(memoryRequests)}
+	plan.Limits = ResourceQuantities{CPU: formatCPU(cpuLimits), Memory: formatMemory(memoryLimits)}
+
+	for _, doc := range []configDocument{solutionDoc, envDoc} {
+		for key, value := range doc.Spec.Quota {
+			plan.Quota[key] = value
+		}
+	}
+	totals := map[string]float64{
+		"requests.cpu":    cpuRequests,
+		"requests.memory": memoryRequests,
+		"limits.cpu":      cpuLimits,
+		"limits.memory":   memoryLimits,
+		"pods":            float64(plan.Pods),
+	}
+	formatted := map[string]string{
+		"requests.cpu":    plan.Requests.CPU,
+		"requests.memory": plan.Requests.Memory,
+		"limits.cpu":      plan.Limits.CPU,
+		"limits.memory":   plan.Limits.Memory,
+		"pods":            strconv.Itoa(plan.Pods),
+	}
+	for _, key := range sortedKeys(plan.Quota) {
+		path := "spec.quota." + key
+		total, known := totals[key]
+		if !known {
+			report(path, "unknown quota %q, use one of %s", key, strings.Join(quotaKeys, ", "))
+			continue
+		}
+		limit, err := parseQuantity(plan.Quota[key])
+		if err != nil {
+			report(path, "%s", err.Error())
+			continue
+		}
+		// Allow for rounding when summing fractional quantities
+		if total > limit*(1+1e-9) {
+			report(path, "environment needs %s %s but its quota allows %s", formatted[key], key, plan.Quota[key])
+		}
+	}
+
+	sort.SliceStable(plan.Issues, func(i, j int) bool {
+		return plan.Issues[i].Path < plan.Issues[j].Path
+	})
+	return plan
+}
+
+// hasComponent reports whether module has a component with the given ID
+func hasComponent(module Module, componentID string) bool {
+	for _, component := range module.Components {
+		if component.ID == componentID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RollbackPlan describes what rolling an environment back to a snapshot in its deployment
+// history would change. Modules is the reverse of what happened since, ConfigRevision the
+// configuration revision that is restored, 0 for the default configuration. A plan with
+// violations, such as changes the environment's policies don't allow, is blocked and can't
+// be applied.
+type RollbackPlan struct {
+	SolutionID     string            `json:"solutionId"`
+	EnvironmentID  string            `json:"environmentId"`
+	SnapshotID     string            `json:"snapshotId"`
+	Modules        []ModulePromotion `json:"modules"`
+	ConfigRevision int               `json:"configRevision"`
+	ConfigChanges  []ConfigChange    `json:"configChanges"`
+	Violations     []string          `json:"violations"`
+	Blocked        bool              `json:"blocked"`
+}
+
+// rollback plans rolling back an environment to a snapshot and returns the plan along with
+// the modules and configuration the environment would end up with
+func (s *SolutionService) rollback(solutionId string, environmentId string, snapshotId string) (RollbackPlan, []EnvironmentModule, string, error) {
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return RollbackPlan{}, nil, "", err
+	}
+	if isTransitional(env.Status) {
+		return RollbackPlan{}, nil, "", fmt.Errorf("environment is %s, wait for it to finish", env.Status)
+	}
+	events, err := s.loadDeploymentHistory(solutionId, environmentId)
+	if err != nil {
+		return RollbackPlan{}, nil, "", err
+	}
+	var snapshot *DeploymentEvent
+	for i := range events {
+		if events[i].ID == snapshotId {
+			snapshot = &events[i]
+		}
+	}
+	if snapshot == nil {
+		return RollbackPlan{}, nil, "", fmt.Errorf("snapshot not found")
+	}
+
+	plan := RollbackPlan{
+		SolutionID:     solutionId,
+		EnvironmentID:  environmentId,
+		SnapshotID:     snapshotId,
+		Modules:        []ModulePromotion{},
+		ConfigRevision: snapshot.ConfigRevision,
+		ConfigChanges:  []ConfigChange{},
+		Violations:     []string{},
+	}
+	violate := func(format string, args ...any) {
+		plan.Violations = append(plan.Violations, fmt.Sprintf(format, args...))
+	}
+
+	// Modules go back to the versions of the snapshot, anything installed since is removed.
+	// Outside of development the environment's policies still hold: modules are only
+	// installed in development environments and, as with promotions, never downgraded.
+	stage := environmentStage(*env)
+	current := make(map[string]EnvironmentModule, len(env.Modules))
+	for _, module := range env.Modules {
+		current[module.ModuleID] = module
+	}
+	modules := []EnvironmentModule{}
+	restored := make(map[string]bool, len(snapshot.Modules))
+	for _, module := range snapshot.Modules {
+		restored[module.ModuleID] = true
+		change := ModulePromotion{ModuleID: module.ModuleID, Action: PromotionActionInstall, ToVersion: module.Version, ConfigChanges: []ConfigChange{}}
+		status := EnvironmentStatusStopped
+		if installed, ok := current[module.ModuleID]; ok {
+			change.FromVersion = installed.Version
+			status = installed.Status
+			switch compareVersions(module.Version, installed.Version) {
+			case 1:
+				change.Action = PromotionActionUpgrade
+			case -1:
+				change.Action = PromotionActionDowngrade
+				if stage != EnvironmentStageDevelopment {
+					violate("rolling back would downgrade %q in %s from %s to %s, modules are only downgraded in development environments", module.ModuleID, env.Name, installed.Version, module.Version)
+				}
+			default:
+				change.Action = PromotionActionUnchanged
+			}
+		} else {
+			if stage != EnvironmentStageDevelopment {
+				violate("rolling back would install %q again in %s, modules are only installed in development environments", module.ModuleID, env.Name)
+			}
+			if s.modules != nil && s.modules.findModule(module.ModuleID) == nil {
+				violate("module %q is no longer in the catalog and can't be installed again", module.ModuleID)
+			}
+		}
+		modules = append(modules, EnvironmentModule{ModuleID: module.ModuleID, Version: module.Version, Status: status})
+		plan.Modules = append(plan.Modules, change)
+	}
+	for _, module := range env.Modules {
+		if !restored[module.ModuleID] {
+			plan.Modules = append(plan.Modules, ModulePromotion{
+				ModuleID:      module.ModuleID,
+				Action:        PromotionActionUninstall,
+				FromVersion:   module.Version,
+				ConfigChanges: []ConfigChange{},
+			})
+		}
+	}
+
+	// The configuration goes back to the revision that was current at the time of the snapshot
+	target := env.clone()
+	target.Modules = modules
+	currentContent, err := s.currentConfigContent(solutionId, *env)
+	if err != nil {
+		return RollbackPlan{}, nil, "", err
+	}
+	content := defaultEnvironmentConfig(target)
+	if snapshot.ConfigRevision > 0 {
+		revision, err := s.configRevision(solutionId, environmentId, snapshot.ConfigRevision)
+		if err != nil {
+			violate("configuration revision %d of the snapshot can't be restored: %v", snapshot.ConfigRevision, err)
+			content = currentContent
+		} else {
+			content = revision.YAML
+		}
+	}
+	if changes, err := diffConfigDocuments(currentContent, content); err == nil {
+		plan.ConfigChanges = changes
+	}
+
+	// What was valid then has to be valid now, module schemas and secrets may have changed since
+	doc, parsed, issues := parseEnvironmentConfig(content, target)
+	if len(issues) == 0 {
+		solutionDoc, err := s.loadSolutionDocument(solutionId)
+		if err != nil {
+			return RollbackPlan{}, nil, "", err
+		}
+		issues = s.validateEffectiveConfig(s.effectiveConfig(solutionId, target, solutionDoc, parsed), doc.Content[0], "")
+	}
+	for _, issue := range issues {
+		violate("restored configuration would be invalid: %s", issue.Message)
+	}
+
+	plan.Blocked = len(plan.Violations) > 0
+	return plan, modules, content, nil
+}
+
+// PlanRollback shows what rolling an environment back to a snapshot in its deployment
+// history would change without applying anything, see GetDeploymentHistory
+func (s *SolutionService) PlanRollback(solutionId string, environmentId string, snapshotId string) (RollbackPlan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	plan, _, _, err := s.rollback(solutionId, environmentId, snapshotId)
+	return plan, err
+}
+
+// RollbackEnvironment restores the module versions and configuration an environment had
+// at a snapshot in its deployment history, see PlanRollback, and deploys them. Nothing is
+// changed if the plan is blocked. The rollback is applied like any other deployment, so
+// it's recorded in the configuration and deployment history and can itself be rolled
+// back. The deployment runs in the background, the returned operation ID tracks it.
+func (s *SolutionService) RollbackEnvironment(solutionId string, environmentId string, snapshotId string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan, modules, content, err := s.rollback(solutionId, environmentId, snapshotId)
+	if err != nil {
+		return "", err
+	}
+	if plan.Blocked {
+		return "", fmt.Errorf("rollback is blocked: %s", strings.Join(plan.Violations, "; "))
+	}
+
+	_, env, _ := s.findEnvironment(solutionId, environmentId)
+	removed, err := s.removedComponents(solutionId, *env, modules)
+	if err != nil {
+		return "", err
+	}
+	message := fmt.Sprintf("Rolled back to snapshot %s", snapshotId)
+	result, err := s.applyDeployment(solutionId, env, modules, content, ConfigRevision{
+		Message:      message,
+		RollbackFrom: plan.ConfigRevision,
+	}, DeploymentEventRollback, message)
+	if err != nil {
+		return "", err
+	}
+	if !result.Saved {
+		return "", fmt.Errorf("restored configuration is invalid: %s", result.Issues[0].Message)
+	}
+	return s.rollOut(solutionId, env, removed, "rollback", fmt.Sprintf("Roll back %s to snapshot %s", env.Name, snapshotId))
+}
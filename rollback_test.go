@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRollbackPolicies rolls environments back to snapshots that downgrade a module and
+// install one again, which only development environments allow
+func TestRollbackPolicies(t *testing.T) {
+	tests := []struct {
+		name          string
+		environmentId string
+		snapshot      []EnvironmentModule
+		violations    []string
+	}{
+		{
+			name:          "development downgrade",
+			environmentId: "dev",
+			snapshot:      []EnvironmentModule{{ModuleID: "flow", Version: "0.9.0"}},
+		},
+		{
+			name:          "development install",
+			environmentId: "dev",
+			snapshot:      []EnvironmentModule{{ModuleID: "flow", Version: "1.0.0"}, {ModuleID: "control-panel", Version: "1.0.0"}},
+		},
+		{
+			name:          "production downgrade",
+			environmentId: "prod",
+			snapshot:      []EnvironmentModule{{ModuleID: "flow", Version: "0.9.0"}},
+			violations:    []string{`would downgrade "flow" in Production from 1.0.0 to 0.9.0`},
+		},
+		{
+			name:          "production install",
+			environmentId: "prod",
+			snapshot:      []EnvironmentModule{{ModuleID: "flow", Version: "1.0.0"}, {ModuleID: "control-panel", Version: "1.0.0"}},
+			violations:    []string{`would install "control-panel" again in Production`},
+		},
+		{
+			name:          "production upgrade",
+			environmentId: "prod",
+			snapshot:      []EnvironmentModule{{ModuleID: "flow", Version: "1.1.0"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSolutionService(t)
+			const solutionId = "production-solution"
+			_, env, err := s.findEnvironment(solutionId, tt.environmentId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			env.Status = EnvironmentStatusRunning
+			snapshot := env.clone()
+			snapshot.Modules = tt.snapshot
+			if err := s.appendDeployment(solutionId, snapshot, DeploymentEventConfig, "Snapshot", nil); err != nil {
+				t.Fatal(err)
+			}
+			// Since the snapshot control-panel was uninstalled
+			env.Modules = env.Modules[:1]
+
+			events, err := s.GetDeploymentHistory(solutionId, tt.environmentId)
+			if err != nil || len(events) == 0 {
+				t.Fatalf("GetDeploymentHistory: %v %v", events, err)
+			}
+			plan, err := s.PlanRollback(solutionId, tt.environmentId, events[0].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(plan.Violations) != len(tt.violations) {
+				t.Fatalf("violations = %q, want %q", plan.Violations, tt.violations)
+			}
+			for i, want := range tt.violations {
+				if !strings.Contains(plan.Violations[i], want) {
+					t.Errorf("violation %q doesn't contain %q", plan.Violations[i], want)
+				}
+			}
+			if plan.Blocked != (len(tt.violations) > 0) {
+				t.Errorf("blocked = %v with violations %q", plan.Blocked, plan.Violations)
+			}
+
+			_, err = s.RollbackEnvironment(solutionId, tt.environmentId, events[0].ID)
+			switch {
+			case plan.Blocked && (err == nil || !strings.Contains(err.Error(), "rollback is blocked")):
+				t.Errorf("blocked rollback returned %v", err)
+			case !plan.Blocked && err != nil:
+				t.Errorf("RollbackEnvironment: %v", err)
+			}
+			waitForOperations(t, s)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// moduleRollout is how a module of an environment is deployed. Modules with a chart are
+// deployed by rendering it, the others by scaling their components.
+type moduleRollout struct {
+	moduleID   string
+	components []ComponentResourcePlan
+	release    *chartRelease
+}
+
+// moduleRollout prepares deploying a module of env with its stored configuration
+func (s *SolutionService) moduleRollout(solutionId string, env Environment, moduleId string) (moduleRollout, error) {
+	components, err := s.moduleComponents(solutionId, env, moduleId)
+	if err != nil {
+		return moduleRollout{}, err
+	}
+	release, err := s.chartRelease(solutionId, env, moduleId, true)
+	if err != nil {
+		return moduleRollout{}, err
+	}
+	return moduleRollout{moduleID: moduleId, components: components, release: release}, nil
+}
+
+// steps are the operation steps deploying the module takes
+func (m moduleRollout) steps() []string {
+	if m.release == nil {
+		return []string{fmt.Sprintf("Deploy %s", m.moduleID)}
+	}
+	return []string{fmt.Sprintf("Render %s chart", m.moduleID), fmt.Sprintf("Deploy %s", m.moduleID)}
+}
+
+// deployModule runs the steps of m in an operation
+func (s *SolutionService) deployModule(ctx context.Context, run *operationRun, solutionId string, env Environment, m moduleRollout) error {
+	steps := m.steps()
+	run.step(steps[0])
+	if m.release == nil {
+		return s.deployer.Scale(ctx, solutionId, env, m.components)
+	}
+	manifests, err := s.render(ctx, *m.release)
+	if err != nil {
+		return err
+	}
+	run.step(steps[1])
+	return s.deployer.Apply(ctx, solutionId, env, manifests)
+}
+
+// removedComponents returns the components of the modules of env that aren't in modules,
+// scaled to zero
+func (s *SolutionService) removedComponents(solutionId string, env Environment, modules []EnvironmentModule) ([]ComponentResourcePlan, error) {
+	var removed []ComponentResourcePlan
+	for _, module := range env.Modules {
+		if slices.ContainsFunc(modules, func(m EnvironmentModule) bool { return m.ModuleID == module.ModuleID }) {
+			continue
+		}
+		components, err := s.moduleComponents(solutionId, env, module.ModuleID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range components {
+			components[i].Replicas = 0
+		}
+		removed = append(removed, components...)
+	}
+	return removed, nil
+}
+
+// rollOut deploys the modules and configuration env was just changed to, see
+// applyDeployment, in an operation and returns its ID. removed are the components of
+// modules the change uninstalled, see removedComponents. The environment is deploying
+// until the operation finishes, see finishRollout. An environment in error is deployed as
+// if it was running. s.mu must be held.
+func (s *SolutionService) rollOut(solutionId string, env *Environment, removed []ComponentResourcePlan, kind string, title string) (string, error) {
+	target := env.clone()
+	if target.Status == EnvironmentStatusError {
+		target.Status = EnvironmentStatusRunning
+	}
+
+	var steps []string
+	if len(removed) > 0 {
+		steps = append(steps, "Remove uninstalled modules")
+	}
+	rollouts := make([]moduleRollout, 0, len(target.Modules))
+	for _, module := range target.Modules {
+		m, err := s.moduleRollout(solutionId, target, module.ModuleID)
+		if err != nil {
+			env.Status = EnvironmentStatusError
+			return "", fmt.Errorf("the change was saved but can't be deployed: %w", err)
+		}
+		rollouts = append(rollouts, m)
+		steps = append(steps, m.steps()...)
+	}
+	steps = append(steps, "Update environment")
+	env.Status = EnvironmentStatusDeploying
+
+	op := s.operations.start(Operation{
+		Kind:          kind,
+		Title:         title,
+		SolutionID:    solutionId,
+		EnvironmentID: target.ID,
+	}, steps, func(ctx context.Context, run *operationRun) error {
+		ctx, cancel := context.WithTimeout(ctx, deployTimeout)
+		defer cancel()
+
+		deployed := make(map[string]bool, len(rollouts))
+		if len(removed) > 0 {
+			run.step("Remove uninstalled modules")
+			if err := s.deployer.Scale(ctx, solutionId, target, removed); err != nil {
+				s.finishRollout(solutionId, target.ID, target.Status, deployed, "", false)
+				return fmt.Errorf("failed to remove uninstalled modules: %w", err)
+			}
+		}
+		for _, m := range rollouts {
+			if err := s.deployModule(ctx, run, solutionId, target, m); err != nil {
+				s.finishRollout(solutionId, target.ID, target.Status, deployed, m.moduleID, false)
+				return fmt.Errorf("failed to deploy %s: %w", m.moduleID, err)
+			}
+			deployed[m.moduleID] = true
+		}
+
+		run.step("Update environment")
+		s.finishRollout(solutionId, target.ID, target.Status, deployed, "", true)
+		return nil
+	})
+	return op.ID, nil
+}
+
+// finishRollout settles an environment after rollOut. Deployed modules are in status and
+// the one that failed to deploy, if any, is in error. The environment is in status if the
+// rollout is done and in error otherwise.
+func (s *SolutionService) finishRollout(solutionId string, environmentId string, status EnvironmentStatus, deployed map[string]bool, failed string, done bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return
+	}
+	env.Status = status
+	if !done {
+		env.Status = EnvironmentStatusError
+	}
+	for i := range env.Modules {
+		module := &env.Modules[i]
+		switch {
+		case deployed[module.ModuleID]:
+			module.Status = status
+		case module.ModuleID == failed:
+			module.Status = EnvironmentStatusError
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchField identifies the part of a module a search hit came from
+type SearchField string
+
+const (
+	SearchFieldName        SearchField = "name"
+	SearchFieldTags        SearchField = "tags"
+	SearchFieldComponents  SearchField = "components"
+	SearchFieldMaintainer  SearchField = "maintainer"
+	SearchFieldDescription SearchField = "description"
+	SearchFieldReadme      SearchField = "readme"
+)
+
+// searchFieldWeights boosts hits in short, descriptive fields over hits in long ones
+var searchFieldWeights = map[SearchField]float64{
+	SearchFieldName:        5,
+	SearchFieldTags:        3,
+	SearchFieldComponents:  2,
+	SearchFieldMaintainer:  2,
+	SearchFieldDescription: 1.5,
+	SearchFieldReadme:      0.5,
+}
+
+// Match quality multipliers for the different ways a query term can match an indexed term
+const (
+	matchExact  = 1.0
+	matchPrefix = 0.8
+	matchFuzzy  = 0.6
+)
+
+// snippetRadius is the number of runes kept either side of the first match in a snippet
+const snippetRadius = 60
+
+// SearchHighlight is a snippet of a matching field. Snippet is HTML escaped with
+// the matched terms wrapped in <mark>.
+type SearchHighlight struct {
+	Field   SearchField `json:"field"`
+	Snippet string      `json:"snippet"`
+}
+
+type ModuleSearchResult struct {
+	Module     ModuleResponse    `json:"module"`
+	Score      float64           `json:"score"`
+	Highlights []SearchHighlight `json:"highlights"`
+}
+
+// searchIndex is an in-memory inverted index over the module catalog
+type searchIndex struct {
+	mu       sync.RWMutex
+	docs     map[string]map[SearchField]string
+	order    []string
+	postings map[string]map[string]map[SearchField]int // term -> module ID -> field -> term frequency
+	lengths  map[string]map[SearchField]int
+}
+
+type searchToken struct {
+	term       string
+	start, end int // byte offsets into the original text
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		docs:     make(map[string]map[SearchField]string),
+		postings: make(map[string]map[string]map[SearchField]int),
+		lengths:  make(map[string]map[SearchField]int),
+	}
+}
+
+// moduleSearchFields returns the indexed text of a module, excluding the README
+func moduleSearchFields(m Module) map[SearchField]string {
+	components := make([]string, 0, len(m.Components))
+	for _, component := range m.Components {
+		components = append(components, component.Name)
+	}
+	return map[SearchField]string{
+		SearchFieldName:        m.Name,
+		SearchFieldTags:        strings.Join(m.Tags, ", "),
+		SearchFieldComponents:  strings.Join(components, ", "),
+		SearchFieldMaintainer:  m.Maintainer,
+		SearchFieldDescription: m.Description,
+	}
+}
+
+// Index adds or replaces the given fields of a module. Fields that aren't passed keep their indexed text.
+func (idx *searchIndex) Index(id string, fields map[SearchField]string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	doc, ok := idx.docs[id]
+	if !ok {
+		doc = make(map[SearchField]string)
+		idx.docs[id] = doc
+		idx.lengths[id] = make(map[SearchField]int)
+		idx.order = append(idx.order, id)
+	}
+
+	for field, text := range fields {
+		if old, ok := doc[field]; ok {
+			for _, tok := range tokenize(old) {
+				idx.removePosting(tok.term, id, field)
+			}
+		}
+
+		tokens := tokenize(text)
+		for _, tok := range tokens {
+			docs, ok := idx.postings[tok.term]
+			if !ok {
+				docs = make(map[string]map[SearchField]int)
+				idx.postings[tok.term] = docs
+			}
+			if docs[id] == nil {
+				docs[id] = make(map[SearchField]int)
+			}
+			docs[id][field]++
+		}
+		doc[field] = text
+		idx.lengths[id][field] = len(tokens)
+	}
+}
+
+func (idx *searchIndex) removePosting(term, id string, field SearchField) {
+	docs := idx.postings[term]
+	if docs == nil || docs[id] == nil {
+		return
+	}
+	delete(docs[id], field)
+	if len(docs[id]) == 0 {
+		delete(docs, id)
+	}
+	if len(docs) == 0 {
+		delete(idx.postings, term)
+	}
+}
+
+type searchHit struct {
+	id    string
+	score float64
+	terms map[string]bool // matched index terms, used for highlighting
+}
+
+// Search returns the IDs of modules matching every term of the query, best match first
+func (idx *searchIndex) Search(query string) []searchHit {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	queryTokens := tokenize(query)
+	if len(queryTokens) == 0 {
+		return nil
+	}
+
+	averages := idx.averageLengths()
+	hits := make(map[string]*searchHit)
+
+	for i, qt := range queryTokens {
+		matched := make(map[string]bool)
+		for term, quality := range idx.expandTerm(qt.term) {
+			docs := idx.postings[term]
+			idf := math.Log(1 + (float64(len(idx.docs))-float64(len(docs))+0.5)/(float64(len(docs))+0.5))
+			for id, fields := range docs {
+				// Every query term has to match, so only the first term may introduce new hits
+				hit, ok := hits[id]
+				if !ok && i > 0 {
+					continue
+				}
+				if !ok {
+					hit = &searchHit{id: id, terms: make(map[string]bool)}
+					hits[id] = hit
+				}
+				for field, tf := range fields {
+					hit.score += quality * searchFieldWeights[field] * idf * bm25TF(tf, idx.lengths[id][field], averages[field])
+				}
+				hit.terms[term] = true
+				matched[id] = true
+			}
+		}
+		for id := range hits {
+			if !matched[id] {
+				delete(hits, id)
+			}
+		}
+	}
+
+	results := make([]searchHit, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, *hit)
+	}
+
+	// Ties keep catalog order so results are stable
+	position := make(map[string]int, len(idx.order))
+	for i, id := range idx.order {
+		position[id] = i
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return position[results[i].id] < position[results[j].id]
+	})
+	return results
+}
+
+// expandTerm returns the index terms a query term matches with the quality of each match.
+// Terms match exactly, as a prefix of a longer term, or within a small edit distance.
+func (idx *searchIndex) expandTerm(term string) map[string]float64 {
+	matches := make(map[string]float64)
+	maxDistance := typoTolerance(term)
+	for candidate := range idx.postings {
+		switch {
+		case candidate == term:
+			matches[candidate] = matchExact
+		case utf8.RuneCountInString(term) >= 2 && strings.HasPrefix(candidate, term):
+			matches[candidate] = matchPrefix
+		case maxDistance > 0:
+			if d := editDistance(term, candidate, maxDistance); d <= maxDistance {
+				matches[candidate] = matchFuzzy / float64(d)
+			}
+		}
+	}
+	return matches
+}
+
+// typoTolerance returns how many edits a query term may be away from an indexed term
+func typoTolerance(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (idx *searchIndex) averageLengths() map[SearchField]float64 {
+	totals := make(map[SearchField]float64)
+	for _, fields := range idx.lengths {
+		for field, n := range fields {
+			totals[field] += float64(n)
+		}
+	}
+	for field := range totals {
+		totals[field] /= float64(len(idx.lengths))
+	}
+	return totals
+}
+
+// bm25TF is the BM25 term frequency component with the usual k1 and b parameters
+func bm25TF(tf, length int, average float64) float64 {
+	const k1, b = 1.2, 0.75
+	norm := 1.0
+	if average > 0 {
+		norm = 1 - b + b*float64(length)/average
+	}
+	return float64(tf) * (k1 + 1) / (float64(tf) + k1*norm)
+}
+
+// Highlights returns a snippet for every field of the module that contains one of the terms
+func (idx *searchIndex) Highlights(id string, terms map[string]bool) []SearchHighlight {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var highlights []SearchHighlight
+	for _, field := range []SearchField{SearchFieldName, SearchFieldDescription, SearchFieldTags, SearchFieldComponents, SearchFieldMaintainer, SearchFieldReadme} {
+		text, ok := idx.docs[id][field]
+		if !ok {
+			continue
+		}
+		if snippet, ok := highlightSnippet(text, terms); ok {
+			highlights = append(highlights, SearchHighlight{Field: field, Snippet: snippet})
+		}
+	}
+	return highlights
+}
+
+// highlightSnippet marks the matched terms in text, trimming long text around the first match
+func highlightSnippet(text string, terms map[string]bool) (string, bool) {
+	var matches []searchToken
+	for _, tok := range tokenize(text) {
+		if terms[tok.term] {
+			matches = append(matches, tok)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	start, end := 0, len(text)
+	if utf8.RuneCountInString(text) > 2*snippetRadius {
+		start = moveRunes(text, matches[0].start, -snippetRadius)
+		end = moveRunes(text, matches[0].end, snippetRadius)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, m := range matches {
+		if m.start < pos || m.end > end {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:m.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[m.start:m.end]))
+		b.WriteString("</mark>")
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return strings.Join(strings.Fields(b.String()), " "), true
+}
+
+// moveRunes moves a byte offset n runes forwards or backwards, clamped to the text
+func moveRunes(text string, offset, n int) int {
+	for ; n < 0 && offset > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:offset])
+		offset -= size
+	}
+	for ; n > 0 && offset < len(text); n-- {
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// tokenize splits text into lowercase letter and digit runs
+func tokenize(text string) []searchToken {
+	var tokens []searchToken
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		}
+		if !isWord && start >= 0 {
+			tokens = append(tokens, searchToken{term: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, searchToken{term: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+// editDistance returns the Damerau-Levenshtein (optimal string alignment) distance
+// between a and b, or max+1 as soon as it is known to exceed max
+func editDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// SecretRef refers to a secret instead of holding its value. In configuration it's written
+// as {secretRef: {vault: name}} for a secret in the local vault, or as
+// {secretRef: {kubernetes: name, key: key}} for an entry of a Kubernetes Secret in the
+// environment's namespace.
+type SecretRef struct {
+	Vault      string `json:"vault,omitempty" yaml:"vault,omitempty"`
+	Kubernetes string `json:"kubernetes,omitempty" yaml:"kubernetes,omitempty"`
+	Key        string `json:"key,omitempty" yaml:"key,omitempty"`
+}
+
+func (r SecretRef) String() string {
+	if r.Vault != "" {
+		return "vault:" + r.Vault
+	}
+	return "kubernetes:" + r.Kubernetes + "/" + r.Key
+}
+
+// value returns the reference the way it's written in configuration
+func (r SecretRef) value() map[string]any {
+	fields := map[string]any{}
+	if r.Vault != "" {
+		fields["vault"] = r.Vault
+	}
+	if r.Kubernetes != "" {
+		fields["kubernetes"] = r.Kubernetes
+		fields["key"] = r.Key
+	}
+	return map[string]any{"secretRef": fields}
+}
+
+// secretMask replaces secret values wherever they would be shown
+const secretMask = "********"
+
+// asSecretRef returns the reference value holds, ok is false if value isn't a reference.
+// A malformed reference returns ok with an error describing the problem.
+func asSecretRef(value any) (ref SecretRef, ok bool, err error) {
+	mapping, isMap := value.(map[string]any)
+	if !isMap {
+		return SecretRef{}, false, nil
+	}
+	raw, isRef := mapping["secretRef"]
+	if !isRef {
+		return SecretRef{}, false, nil
+	}
+	if len(mapping) > 1 {
+		return SecretRef{}, true, fmt.Errorf("secretRef can't be combined with other keys")
+	}
+
+	fields, isMap := raw.(map[string]any)
+	if !isMap {
+		return SecretRef{}, true, fmt.Errorf("secretRef must be a mapping with vault or kubernetes")
+	}
+	for _, key := range sortedKeys(fields) {
+		s, isString := fields[key].(string)
+		if !isString {
+			return SecretRef{}, true, fmt.Errorf("secretRef.%s must be a string", key)
+		}
+		switch key {
+		case "vault":
+			ref.Vault = s
+		case "kubernetes":
+			ref.Kubernetes = s
+		case "key":
+			ref.Key = s
+		default:
+			return SecretRef{}, true, fmt.Errorf("unknown field %q in secretRef", key)
+		}
+	}
+
+	switch {
+	case ref.Vault != "" && ref.Kubernetes != "":
+		return SecretRef{}, true, fmt.Errorf("secretRef must set either vault or kubernetes, not both")
+	case ref.Vault != "":
+		if ref.Key != "" {
+			return SecretRef{}, true, fmt.Errorf("secretRef.key is only used with kubernetes")
+		}
+	case ref.Kubernetes != "":
+		if ref.Key == "" {
+			return SecretRef{}, true, fmt.Errorf("secretRef.key is required with kubernetes")
+		}
+	default:
+		return SecretRef{}, true, fmt.Errorf("secretRef must set vault or kubernetes")
+	}
+	return ref, true, nil
+}
+
+// replaceSecretRefs returns a copy of values with every secret reference replaced by the
+// result of replace, which gets the dotted path and the reference
+func replaceSecretRefs(prefix string, values map[string]any, replace func(path string, ref SecretRef, err error) (any, error)) (map[string]any, error) {
+	result := make(map[string]any, len(values))
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		ref, isRef, refErr := asSecretRef(value)
+		switch {
+		case isRef:
+			replaced, err := replace(path, ref, refErr)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = replaced
+		default:
+			if nested, ok := value.(map[string]any); ok {
+				replaced, err := replaceSecretRefs(path, nested, replace)
+				if err != nil {
+					return nil, err
+				}
+				result[key] = replaced
+			} else {
+				result[key] = value
+			}
+		}
+	}
+	return result, nil
+}
+
+// validateModuleValues validates the merged values of a module against its schema. Secret
+// references must be well formed and point at an existing vault secret, and properties
+// the schema declares writeOnly must be references rather than plain values. The values
+// behind references are unknown until deployment, so the schema isn't checked for them.
+func (s *SolutionService) validateModuleValues(solutionId string, schema *jsonschema.Schema, values map[string]any) ([]schemaViolation, error) {
+	var violations []schemaViolation
+	secretPaths := make(map[string]bool)
+	placeholders, _ := replaceSecretRefs("", values, func(path string, ref SecretRef, err error) (any, error) {
+		secretPaths[path] = true
+		switch {
+		case err != nil:
+			violations = append(violations, schemaViolation{Path: path, Message: err.Error()})
+		case ref.Vault != "" && !s.hasVaultSecret(solutionId, ref.Vault):
+			violations = append(violations, schemaViolation{Path: path, Message: fmt.Sprintf("secret %q does not exist in the vault", ref.Vault)})
+		}
+		return secretMask, nil
+	})
+
+	if schema == nil {
+		return violations, nil
+	}
+	for _, key := range sortedKeys(schema.Properties) {
+		if _, set := values[key]; set && schema.Properties[key].WriteOnly && !secretPaths[key] {
+			violations = append(violations, schemaViolation{Path: key, Message: fmt.Sprintf("%s is a secret, refer to it with secretRef instead of setting its value", key)})
+		}
+	}
+
+	schemaViolations, err := validateSchemaValues(schema, placeholders)
+	if err != nil {
+		return nil, err
+	}
+	for _, violation := range schemaViolations {
+		if !isSecretPath(secretPaths, violation.Path) {
+			violations = append(violations, violation)
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+	return violations, nil
+}
+
+// isSecretPath reports whether path is a secret reference or lies below one
+func isSecretPath(secretPaths map[string]bool, path string) bool {
+	for secretPath := range secretPaths {
+		if path == secretPath || strings.HasPrefix(path, secretPath+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// materializeValues resolves the vault references in a module's values. It's only meant to
+// be called when deploying, the result must not be stored or returned to the frontend.
+// References to Kubernetes Secrets are kept as they are since the cluster resolves those
+// itself. Resolved values are masked in logs from then on.
+func (s *SolutionService) materializeValues(solutionId string, values map[string]any) (map[string]any, error) {
+	return replaceSecretRefs("", values, func(path string, ref SecretRef, err error) (any, error) {
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if ref.Kubernetes != "" {
+			return ref.value(), nil
+		}
+		value, err := s.readVaultSecret(solutionId, ref.Vault)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		secretMasker.Add(value)
+		return value, nil
+	})
+}
+
+// secretMasker holds the secret values that were materialised so they can be masked in logs
+var secretMasker = newMasker()
+
+type masker struct {
+	mu       sync.RWMutex
+	replacer *strings.Replacer
+	values   map[string]bool
+}
+
+func newMasker() *masker {
+	return &masker{values: make(map[string]bool), replacer: strings.NewReplacer()}
+}
+
+// Add registers a value to mask. Very short values are ignored, masking them would
+// garble unrelated text.
+func (m *masker) Add(value string) {
+	if len(value) < 4 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.values[value] {
+		return
+	}
+	m.values[value] = true
+
+	// Longer values first so a secret containing another one is masked as a whole
+	values := make([]string, 0, len(m.values))
+	for v := range m.values {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	pairs := make([]string, 0, 2*len(values))
+	for _, v := range values {
+		pairs = append(pairs, v, secretMask)
+	}
+	m.replacer = strings.NewReplacer(pairs...)
+}
+
+// Mask replaces every registered secret value in text
+func (m *masker) Mask(text string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.replacer.Replace(text)
+}
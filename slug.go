@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxSlugLength keeps slugs usable as DNS labels, e.g. in namespaces and hostnames
+const maxSlugLength = 63
+
+// transliterations spell common accented letters in ASCII so they survive slugification
+var transliterations = map[rune]string{
+	'æ': "ae", 'ø': "o", 'å': "a", 'ä': "a", 'ö': "o", 'ü': "u", 'ß': "ss",
+	'á': "a", 'à': "a", 'â': "a", 'ã': "a", 'ç': "c", 'é': "e", 'è': "e",
+	'ê': "e", 'ë': "e", 'í': "i", 'ì': "i", 'î': "i", 'ï': "i", 'ñ': "n",
+	'ó': "o", 'ò': "o", 'ô': "o", 'õ': "o", 'ú': "u", 'ù': "u", 'û': "u",
+	'ý': "y", 'ÿ': "y", 'ð': "d", 'þ': "th",
+}
+
+// slugify turns a display name into a lowercase identifier of letters, digits and single
+// dashes, e.g. "Kunde Ærlig (Prod)" becomes "kunde-aerlig-prod"
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case transliterations[r] != "":
+			b.WriteString(transliterations[r])
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimRight(b.String(), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
+
+// uniqueSlug returns base, or base with a numeric suffix if taken reports it's in use
+func uniqueSlug(base string, taken func(slug string) bool) string {
+	slug := base
+	for i := 2; taken(slug); i++ {
+		suffix := fmt.Sprintf("-%d", i)
+		trimmed := base
+		if len(trimmed)+len(suffix) > maxSlugLength {
+			trimmed = strings.TrimRight(trimmed[:maxSlugLength-len(suffix)], "-")
+		}
+		slug = trimmed + suffix
+	}
+	return slug
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type SolutionRequest struct {
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	Organization string           `json:"organization"`
+	Modules      []SolutionModule `json:"modules"`
+}
+
+// solutionDir is where everything stored for a solution lives
+func solutionDir(solutionID string) []string {
+	return []string{"solutions", solutionID}
+}
+
+// environmentDir is where everything stored for an environment lives
+func environmentDir(solutionID string, environmentID string) []string {
+	return []string{"solutions", solutionID, "environments", environmentID}
+}
+
+// validateSolutionRequest checks a create or update request. exceptID is the solution
+// being updated, its own name doesn't count as taken.
+func (s *SolutionService) validateSolutionRequest(req *SolutionRequest, exceptID string) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return fmt.Errorf("solution name is required")
+	}
+	if slugify(req.Name) == "" {
+		return fmt.Errorf("solution name must contain at least one letter or digit")
+	}
+	for _, solution := range s.solutions {
+		if solution.ID != exceptID && strings.EqualFold(solution.Name, req.Name) {
+			return fmt.Errorf("a solution named %q already exists", solution.Name)
+		}
+	}
+
+	// Versions are filled in below, don't touch the caller's slice
+	req.Modules = append([]SolutionModule(nil), req.Modules...)
+	seen := make(map[string]bool)
+	for i, module := range req.Modules {
+		if seen[module.ModuleID] {
+			return fmt.Errorf("module %q is listed more than once", module.ModuleID)
+		}
+		seen[module.ModuleID] = true
+		if s.modules == nil {
+			continue
+		}
+		catalog := s.modules.findModule(module.ModuleID)
+		if catalog == nil {
+			return fmt.Errorf("module %q is not in the catalog", module.ModuleID)
+		}
+		if module.Version == "" {
+			req.Modules[i].Version = catalog.Version
+		}
+	}
+	return nil
+}
+
+// CreateSolution adds a solution. Its ID is derived from the name and made unique, also
+// against data left behind by solutions that are gone, so a new solution never picks up
+// their configuration, secrets or history.
+func (s *SolutionService) CreateSolution(req SolutionRequest) (Solution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.createSolution(req)
+}
+
+// createSolution is CreateSolution for callers that already hold s.mu
+func (s *SolutionService) createSolution(req SolutionRequest) (Solution, error) {
+	if err := s.validateSolutionRequest(&req, ""); err != nil {
+		return Solution{}, err
+	}
+
+	now := time.Now()
+	solution := Solution{
+		ID: uniqueSlug(slugify(req.Name), func(id string) bool {
+			return s.findSolution(id) != nil || s.store.Exists(solutionDir(id)...)
+		}),
+		Name:         req.Name,
+		Description:  req.Description,
+		Organization: req.Organization,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Modules:      append([]SolutionModule{}, req.Modules...),
+		Environments: []Environment{},
+	}
+	s.solutions = append(s.solutions, solution)
+	return solution.clone(), nil
+}
+
+// UpdateSolution renames a solution or changes its description, organization and declared
+// modules. The ID stays the same. Modules are left as they are when req.Modules is nil.
+func (s *SolutionService) UpdateSolution(id string, req SolutionRequest) (Solution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	solution := s.findSolution(id)
+	if solution == nil {
+		return Solution{}, fmt.Errorf("solution not found")
+	}
+	if err := s.validateSolutionRequest(&req, id); err != nil {
+		return Solution{}, err
+	}
+
+	solution.Name = req.Name
+	solution.Description = req.Description
+	solution.Organization = req.Organization
+	if req.Modules != nil {
+		solution.Modules = append([]SolutionModule{}, req.Modules...)
+	}
+	solution.UpdatedAt = time.Now()
+	return solution.clone(), nil
+}
+
+// DeleteSolution removes a solution along with its stored configuration, history and
+// secrets. Solutions with environments that run, are in error or have an operation in
+// progress can't be deleted, stop or delete those environments first.
+func (s *SolutionService) DeleteSolution(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deleteSolution(id)
+}
+
+// deleteSolution is DeleteSolution for callers that already hold s.mu
+func (s *SolutionService) deleteSolution(id string) error {
+	solution := s.findSolution(id)
+	if solution == nil {
+		return fmt.Errorf("solution not found")
+	}
+
+	var running, busy []string
+	for _, env := range solution.Environments {
+		switch {
+		case isTransitional(env.Status):
+			busy = append(busy, fmt.Sprintf("%s is %s", env.Name, env.Status))
+		case env.Status == EnvironmentStatusRunning, env.Status == EnvironmentStatusError:
+			running = append(running, fmt.Sprintf("%s is %s", env.Name, env.Status))
+		}
+	}
+	if len(busy) > 0 {
+		return fmt.Errorf("solution has environments with an operation in progress (%s), wait for it to finish before deleting it", strings.Join(busy, ", "))
+	}
+	if len(running) > 0 {
+		return fmt.Errorf("solution has environments that may still be deployed (%s), stop or delete them before deleting it", strings.Join(running, ", "))
+	}
+
+	if err := s.store.Remove(solutionDir(id)...); err != nil {
+		return fmt.Errorf("failed to remove solution data: %w", err)
+	}
+	for i := range s.solutions {
+		if s.solutions[i].ID == id {
+			s.solutions = append(s.solutions[:i], s.solutions[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// CloneSolution creates a solution with the same description, organization, modules,
+// configuration and secrets as an existing one. Environments aren't cloned since their
+// namespaces have to be unique.
+func (s *SolutionService) CloneSolution(id string, name string) (Solution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source := s.findSolution(id)
+	if source == nil {
+		return Solution{}, fmt.Errorf("solution not found")
+	}
+	clone, err := s.createSolution(SolutionRequest{
+		Name:         name,
+		Description:  source.Description,
+		Organization: source.Organization,
+		Modules:      source.Modules,
+	})
+	if err != nil {
+		return Solution{}, err
+	}
+
+	copyData := func() error {
+		data, ok, err := s.store.Read(solutionConfigPath(id)...)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if err := s.store.Write(data, solutionConfigPath(clone.ID)...); err != nil {
+				return err
+			}
+		}
+
+		// Secrets are bound to their solution, so they're re-encrypted rather than copied
+		secrets, err := s.loadVault(id)
+		if err != nil {
+			return err
+		}
+		for name := range secrets {
+			value, err := s.readVaultSecret(id, name)
+			if err != nil {
+				return err
+			}
+			if err := s.setSecret(clone.ID, name, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := copyData(); err != nil {
+		_ = s.deleteSolution(clone.ID)
+		return Solution{}, fmt.Errorf("failed to clone solution data: %w", err)
+	}
+	return clone, nil
+}
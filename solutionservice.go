@@ -1,13 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
 type SolutionService struct {
 	solutions []Solution
+	store     *fileStore
+	modules   *ModuleService
+	deployer  Deployer
+	// operations runs deployments in the background
+	operations *OperationService
+	// mu guards solutions and the files in store that are read, changed and written back.
+	// Bindings lock it and never hand out pointers into solutions, unexported helpers
+	// expect the caller to hold it.
+	mu sync.RWMutex
 }
 
 type Solution struct {
@@ -32,6 +44,12 @@ const (
 	EnvironmentStatusRunning EnvironmentStatus = "running"
 	EnvironmentStatusStopped EnvironmentStatus = "stopped"
 	EnvironmentStatusError   EnvironmentStatus = "error"
+	// Transitional statuses while a lifecycle operation runs
+	EnvironmentStatusStarting  EnvironmentStatus = "starting"
+	EnvironmentStatusStopping  EnvironmentStatus = "stopping"
+	EnvironmentStatusDeleting  EnvironmentStatus = "deleting"
+	EnvironmentStatusSyncing   EnvironmentStatus = "syncing"
+	EnvironmentStatusDeploying EnvironmentStatus = "deploying"
 )
 
 type Environment struct {
@@ -54,7 +72,7 @@ type AddEnvironmentRequest struct {
 	Namespace string `json:"namespace"`
 }
 
-func NewSolutionService() *SolutionService {
+func NewSolutionService(operations *OperationService) *SolutionService {
 	// Initialize with mock data
 	solutions := []Solution{
 		{
@@ -143,16 +161,57 @@ func NewSolutionService() *SolutionService {
 		},
 	}
 
-	return &SolutionService{
-		solutions: solutions,
+	s := &SolutionService{
+		solutions:  solutions,
+		store:      newFileStore(defaultDataDir()),
+		operations: operations,
 	}
+	s.deployer = newDeployer(s.componentPlan)
+	return s
+}
+
+// clone returns a copy of the solution that shares no slices with it
+func (sol Solution) clone() Solution {
+	sol.Modules = append([]SolutionModule{}, sol.Modules...)
+	environments := make([]Environment, len(sol.Environments))
+	for i, env := range sol.Environments {
+		environments[i] = env.clone()
+	}
+	sol.Environments = environments
+	return sol
+}
+
+// clone returns a copy of the environment that shares no slices with it
+func (env Environment) clone() Environment {
+	env.Modules = append([]EnvironmentModule{}, env.Modules...)
+	return env
 }
 
 func (s *SolutionService) GetSolutions() []Solution {
-	return s.solutions
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	solutions := make([]Solution, len(s.solutions))
+	for i, solution := range s.solutions {
+		solutions[i] = solution.clone()
+	}
+	return solutions
 }
 
 func (s *SolutionService) GetSolution(id string) *Solution {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	solution := s.findSolution(id)
+	if solution == nil {
+		return nil
+	}
+	clone := solution.clone()
+	return &clone
+}
+
+// findSolution returns the solution with the given ID, or nil if there is none
+func (s *SolutionService) findSolution(id string) *Solution {
 	for i := range s.solutions {
 		if s.solutions[i].ID == id {
 			return &s.solutions[i]
@@ -162,22 +221,44 @@ func (s *SolutionService) GetSolution(id string) *Solution {
 }
 
 func (s *SolutionService) GetEnvironments(solutionId string) []Environment {
-	solution := s.GetSolution(solutionId)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	solution := s.findSolution(solutionId)
 	if solution == nil {
 		return nil
 	}
-	return solution.Environments
+	return solution.clone().Environments
 }
 
-func (s *SolutionService) AddEnvironment(solutionId string, req AddEnvironmentRequest) error {
-	solution := s.GetSolution(solutionId)
+func (s *SolutionService) AddEnvironment(solutionId string, req AddEnvironmentRequest) (AddEnvironmentResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	solution := s.findSolution(solutionId)
 	if solution == nil {
-		return fmt.Errorf("solution not found")
+		return AddEnvironmentResult{}, fmt.Errorf("solution not found")
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.Namespace = strings.TrimSpace(req.Namespace)
+	if errors := s.validateEnvironmentRequest(solution, req.Name, req.Namespace, ""); len(errors) > 0 {
+		return AddEnvironmentResult{Errors: errors}, nil
 	}
 
-	// Create a new environment
+	// Create a new environment, its ID is derived from the name and unique within the solution.
+	// IDs of deleted environments stay taken, their events remain in the deployment history.
+	used := make(map[string]bool)
+	if events, err := s.loadDeploymentHistory(solutionId, ""); err == nil {
+		for _, event := range events {
+			used[event.EnvironmentID] = true
+		}
+	}
 	env := Environment{
-		ID:           fmt.Sprintf("%s-%s", solutionId, strings.ToLower(req.Name)),
+		ID: uniqueSlug(slugify(req.Name), func(id string) bool {
+			_, _, err := s.findEnvironment(solutionId, id)
+			return err == nil || used[id] || s.store.Exists(environmentDir(solutionId, id)...)
+		}),
 		Name:         req.Name,
 		Namespace:    req.Namespace,
 		Status:       EnvironmentStatusStopped,
@@ -195,19 +276,32 @@ func (s *SolutionService) AddEnvironment(solutionId string, req AddEnvironmentRe
 	}
 
 	// Add the environment to the solution
-	for i := range s.solutions {
-		if s.solutions[i].ID == solutionId {
-			s.solutions[i].Environments = append(s.solutions[i].Environments, env)
-			s.solutions[i].UpdatedAt = time.Now()
-			break
+	solution.Environments = append(solution.Environments, env)
+	solution.UpdatedAt = time.Now()
+
+	return AddEnvironmentResult{Added: true, Environment: env.clone(), Errors: []FieldError{}}, nil
+}
+
+// findEnvironment returns the solution and environment with the given IDs
+func (s *SolutionService) findEnvironment(solutionId string, environmentId string) (*Solution, *Environment, error) {
+	solution := s.findSolution(solutionId)
+	if solution == nil {
+		return nil, nil, fmt.Errorf("solution not found")
+	}
+	for i := range solution.Environments {
+		if solution.Environments[i].ID == environmentId {
+			return solution, &solution.Environments[i], nil
 		}
 	}
-
-	return nil
+	return nil, nil, fmt.Errorf("environment not found")
 }
 
 // IsDevelopmentEnvironment checks if an environment is a development environment
 func (s *SolutionService) IsDevelopmentEnvironment(env Environment) bool {
+	return isDevelopmentEnvironment(env)
+}
+
+func isDevelopmentEnvironment(env Environment) bool {
 	// Check if the name or namespace contains development-related terms
 	nameLower := strings.ToLower(env.Name)
 	namespaceLower := strings.ToLower(env.Namespace)
@@ -217,12 +311,16 @@ func (s *SolutionService) IsDevelopmentEnvironment(env Environment) bool {
 		   strings.Contains(namespaceLower, "development")
 }
 
-// InstallModule installs a module in a development environment
-func (s *SolutionService) InstallModule(solutionId string, environmentId string, moduleId string, version string) error {
+// InstallModule installs a module in a development environment. The module is deployed in
+// the background, the returned operation ID tracks it, see OperationService.
+func (s *SolutionService) InstallModule(solutionId string, environmentId string, moduleId string, version string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// Find the solution
-	solution := s.GetSolution(solutionId)
+	solution := s.findSolution(solutionId)
 	if solution == nil {
-		return fmt.Errorf("solution not found")
+		return "", fmt.Errorf("solution not found")
 	}
 
 	// Find the environment
@@ -234,27 +332,107 @@ func (s *SolutionService) InstallModule(solutionId string, environmentId string,
 		}
 	}
 	if env == nil {
-		return fmt.Errorf("environment not found")
+		return "", fmt.Errorf("environment not found")
 	}
 
 	// Check if this is a development environment
 	if !s.IsDevelopmentEnvironment(*env) {
-		return fmt.Errorf("module installation is only allowed in development environments")
+		return "", fmt.Errorf("module installation is only allowed in development environments")
+	}
+	if isTransitional(env.Status) {
+		return "", fmt.Errorf("environment is %s, wait for it to finish", env.Status)
 	}
 
 	// Check if module is already installed
 	for _, module := range env.Modules {
 		if module.ModuleID == moduleId {
-			return fmt.Errorf("module is already installed")
+			return "", fmt.Errorf("module is already installed")
 		}
 	}
 
-	// Add the module to the environment
+	// Add the module to the environment, it shows as starting until it's deployed
 	env.Modules = append(env.Modules, EnvironmentModule{
 		ModuleID: moduleId,
 		Version:  version,
-		Status:   EnvironmentStatusStopped,
+		Status:   EnvironmentStatusStarting,
 	})
+	target := env.clone()
+	rollout, err := s.moduleRollout(solutionId, target, moduleId)
+	if err != nil {
+		s.finishInstall(solutionId, environmentId, moduleId, version, false)
+		return "", err
+	}
+	steps := append(rollout.steps(), "Update environment")
+
+	op := s.operations.start(Operation{
+		Kind:          "install",
+		Title:         fmt.Sprintf("Install %s %s in %s", moduleId, version, env.Name),
+		SolutionID:    solutionId,
+		EnvironmentID: environmentId,
+	}, steps, func(ctx context.Context, run *operationRun) error {
+		ctx, cancel := context.WithTimeout(ctx, deployTimeout)
+		defer cancel()
+		if err := s.deployModule(ctx, run, solutionId, target, rollout); err != nil {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.finishInstall(solutionId, environmentId, moduleId, version, false)
+			return fmt.Errorf("failed to deploy %s: %w", moduleId, err)
+		}
+
+		run.step("Update environment")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.finishInstall(solutionId, environmentId, moduleId, version, true)
+		return nil
+	})
+	return op.ID, nil
+}
+
+// moduleComponents returns the components of a module in env with their planned replicas,
+// none are started in an environment that isn't running
+func (s *SolutionService) moduleComponents(solutionId string, env Environment, moduleId string) ([]ComponentResourcePlan, error) {
+	solutionDoc, err := s.loadSolutionDocument(solutionId)
+	if err != nil {
+		return nil, err
+	}
+	envDoc, err := s.loadEnvironmentDocument(solutionId, env)
+	if err != nil {
+		return nil, err
+	}
+	var components []ComponentResourcePlan
+	for _, component := range s.effectiveConfig(solutionId, env, solutionDoc, envDoc).Resources.Components {
+		if component.ModuleID != moduleId {
+			continue
+		}
+		if env.Status != EnvironmentStatusRunning {
+			component.Replicas = 0
+		}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+// finishInstall settles a module InstallModule added. An installed module takes the status
+// of its environment and is added to the solution, a failed one is removed again.
+func (s *SolutionService) finishInstall(solutionId string, environmentId string, moduleId string, version string, installed bool) {
+	solution, env, err := s.findEnvironment(solutionId, environmentId)
+	if err != nil {
+		return
+	}
+	if !installed {
+		env.Modules = slices.DeleteFunc(env.Modules, func(module EnvironmentModule) bool {
+			return module.ModuleID == moduleId
+		})
+		return
+	}
+	for i := range env.Modules {
+		if env.Modules[i].ModuleID == moduleId {
+			env.Modules[i].Status = EnvironmentStatusStopped
+			if env.Status == EnvironmentStatusRunning {
+				env.Modules[i].Status = EnvironmentStatusRunning
+			}
+		}
+	}
 
 	// Add the module to the solution if it's not already there
 	moduleExists := false
@@ -275,5 +453,6 @@ func (s *SolutionService) InstallModule(solutionId string, environmentId string,
 	env.LastDeployed = time.Now()
 	solution.UpdatedAt = time.Now()
 
-	return nil
+	s.recordDeployment(solutionId, *env, DeploymentEventInstall, fmt.Sprintf("Installed %s %s", moduleId, version),
+		[]ModuleVersionChange{{ModuleID: moduleId, After: version}})
 } 
\ No newline at end of file
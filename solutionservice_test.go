@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// instantDeployer deploys without waiting and never sees anything running
+type instantDeployer struct{}
+
+func (instantDeployer) Scale(ctx context.Context, solutionID string, env Environment, components []ComponentResourcePlan) error {
+	return nil
+}
+
+func (instantDeployer) Apply(ctx context.Context, solutionID string, env Environment, release ModuleManifests) error {
+	return nil
+}
+
+func (instantDeployer) Sync(ctx context.Context, solutionID string, env Environment, component ComponentResourcePlan) error {
+	return nil
+}
+
+func (instantDeployer) Teardown(ctx context.Context, solutionID string, env Environment) error {
+	return nil
+}
+
+func (instantDeployer) Status(ctx context.Context, solutionID string, env Environment) ([]componentState, error) {
+	return nil, nil
+}
+
+func (instantDeployer) Logs(ctx context.Context, solutionID string, env Environment, component ComponentResourcePlan, tail int) ([]LogEntry, error) {
+	return nil, nil
+}
+
+// newTestSolutionService returns a service with the mock data, storing in a temporary
+// directory and deploying with instantDeployer
+func newTestSolutionService(t *testing.T) *SolutionService {
+	t.Helper()
+	t.Setenv("BLOCC_UI_DATA_DIR", t.TempDir())
+	t.Setenv(vaultKeyEnv, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	t.Setenv(githubTokenEnv, "")
+
+	s := NewSolutionService(NewOperationService())
+	s.modules = NewModuleService(s)
+	s.deployer = instantDeployer{}
+	// Render the bundled chart rather than fetching flow's from its registry
+	s.modules.findModule("flow").Chart = &ModuleChart{Path: "charts/blocc-module"}
+	return s
+}
+
+// waitForOperations waits until all operations of s have finished
+func waitForOperations(t *testing.T, s *SolutionService) {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		running := 0
+		for _, op := range s.operations.ListOperations("") {
+			if !op.finished() {
+				running++
+			}
+		}
+		if running == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d operations still running", running)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestSolutionServiceConcurrentAccess calls bindings that read and change the same
+// environment from many goroutines. Run it with -race.
+func TestSolutionServiceConcurrentAccess(t *testing.T) {
+	s := newTestSolutionService(t)
+	const solutionId, environmentId = "demo-solution", "dev-1"
+	config, err := s.GetEnvironmentConfig(solutionId, environmentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changes to the environment fail while another one is in progress, only reads and
+	// adding environments have to succeed
+	const workers = 8
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		run := func(f func()) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				f()
+			}()
+		}
+		run(func() {
+			result, err := s.AddEnvironment(solutionId, AddEnvironmentRequest{
+				Name:      fmt.Sprintf("Test %d", i),
+				Namespace: fmt.Sprintf("customer-a-test-%d", i),
+			})
+			if err != nil || !result.Added {
+				t.Errorf("AddEnvironment: %v %v", err, result.Errors)
+			}
+		})
+		run(func() {
+			_, _ = s.InstallModule(solutionId, environmentId, "decision", "1.0.0")
+		})
+		run(func() {
+			for _, solution := range s.GetSolutions() {
+				for _, env := range solution.Environments {
+					_ = len(env.Modules)
+				}
+			}
+		})
+		run(func() {
+			if _, err := s.GetEffectiveConfig(solutionId, environmentId); err != nil {
+				t.Errorf("GetEffectiveConfig: %v", err)
+			}
+		})
+		run(func() {
+			_, _ = s.SaveEnvironmentConfig(solutionId, environmentId, config.YAML, fmt.Sprintf("Save %d", i))
+		})
+		run(func() {
+			_, _ = s.StopEnvironment(solutionId, environmentId)
+			_, _ = s.StartEnvironment(solutionId, environmentId)
+		})
+		run(func() {
+			_, _ = s.SyncComponents(solutionId, environmentId, []string{"flow/process"})
+		})
+		run(func() {
+			_, _ = s.RefreshEnvironmentStatus(solutionId, environmentId)
+		})
+	}
+	wg.Wait()
+	waitForOperations(t, s)
+
+	solution := s.GetSolution(solutionId)
+	added := make(map[string]bool)
+	for _, env := range solution.Environments {
+		if strings.HasPrefix(env.Name, "Test ") {
+			if added[env.ID] {
+				t.Errorf("environment ID %q is used twice", env.ID)
+			}
+			added[env.ID] = true
+		}
+		if isTransitional(env.Status) {
+			t.Errorf("environment %s is still %s", env.ID, env.Status)
+		}
+		if env.ID == environmentId {
+			installed := 0
+			for _, module := range env.Modules {
+				if module.ModuleID == "decision" {
+					installed++
+				}
+			}
+			if installed > 1 {
+				t.Errorf("decision is installed %d times", installed)
+			}
+		}
+	}
+	if len(added) != workers {
+		t.Errorf("added %d environments, want %d", len(added), workers)
+	}
+}
+
+func TestDeleteSolutionWithEnvironments(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses map[string]EnvironmentStatus
+		deleted  bool
+	}{
+		{"operation in progress", map[string]EnvironmentStatus{"dev": EnvironmentStatusStopping, "prod": EnvironmentStatusStopped}, false},
+		{"running", map[string]EnvironmentStatus{"dev": EnvironmentStatusRunning, "prod": EnvironmentStatusStopped}, false},
+		{"error", map[string]EnvironmentStatus{"dev": EnvironmentStatusStopped, "prod": EnvironmentStatusError}, false},
+		{"stopped", map[string]EnvironmentStatus{"dev": EnvironmentStatusStopped, "prod": EnvironmentStatusStopped}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSolutionService(t)
+			const solutionId = "production-solution"
+			for environmentId, status := range tt.statuses {
+				_, env, err := s.findEnvironment(solutionId, environmentId)
+				if err != nil {
+					t.Fatal(err)
+				}
+				env.Status = status
+			}
+
+			err := s.DeleteSolution(solutionId)
+			if deleted := s.GetSolution(solutionId) == nil; deleted != tt.deleted {
+				t.Errorf("deleted = %v, want %v (%v)", deleted, tt.deleted, err)
+			}
+			if tt.deleted != (err == nil) {
+				t.Errorf("DeleteSolution returned %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// dataDirEnv overrides where the app keeps its state, mostly useful during development
+const dataDirEnv = "BLOCC_UI_DATA_DIR"
+
+// defaultDataDir returns the directory the app keeps its state in
+func defaultDataDir() string {
+	if dir := os.Getenv(dataDirEnv); dir != "" {
+		return dir
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "blocc-ui")
+}
+
+// newID returns a random ID for records such as operations and deployment events, which
+// may be created in the same instant
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate an ID: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// fileStore reads and writes files below a root directory
+type fileStore struct {
+	root string
+}
+
+func newFileStore(root string) *fileStore {
+	return &fileStore{root: root}
+}
+
+func (s *fileStore) path(elem ...string) string {
+	return filepath.Join(append([]string{s.root}, elem...)...)
+}
+
+// Read returns the contents of the file, or ok=false if it doesn't exist
+func (s *fileStore) Read(elem ...string) (data []byte, ok bool, err error) {
+	data, err = os.ReadFile(s.path(elem...))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// ModTime returns when the file was last written
+func (s *fileStore) ModTime(elem ...string) (time.Time, error) {
+	info, err := os.Stat(s.path(elem...))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// Write replaces the file atomically so a crash never leaves a half written file behind
+func (s *fileStore) Write(data []byte, elem ...string) error {
+	path := s.path(elem...)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Exists reports whether the file or directory exists. Anything that can't be checked
+// counts as existing so that it's never overwritten by mistake.
+func (s *fileStore) Exists(elem ...string) bool {
+	_, err := os.Stat(s.path(elem...))
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// Remove deletes the file or directory and everything below it
+func (s *fileStore) Remove(elem ...string) error {
+	return os.RemoveAll(s.path(elem...))
+}
+
+// Append adds data to the end of the file, creating it if needed. Unlike Write it doesn't
+// rewrite the file, so it suits logs that only ever grow.
+func (s *fileStore) Append(data []byte, elem ...string) error {
+	path := s.path(elem...)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zalando/go-keyring"
+)
+
+// SecretInfo describes a secret in the local vault. Values are never returned.
+type SecretInfo struct {
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updatedAt" ts_type:"string"`
+}
+
+// vaultEntry is an encrypted secret, Ciphertext starts with the nonce
+type vaultEntry struct {
+	Ciphertext []byte    `json:"ciphertext"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// vaultKeyEnv holds the base64 encoded vault key on machines without an OS keychain, such as
+// CI runners. It takes precedence over the keychain.
+const vaultKeyEnv = "BLOCC_UI_VAULT_KEY"
+
+// vaultKeyringService is the name the vault key is kept under in the OS keychain
+const vaultKeyringService = "blocc-ui"
+
+// vaultKeys caches the vault key of every data directory once it's been read
+var vaultKeys = struct {
+	sync.Mutex
+	keys map[string][]byte
+}{keys: make(map[string][]byte)}
+
+// vaultPath is where the encrypted secrets of a solution are stored
+func vaultPath(solutionID string) []string {
+	return []string{"solutions", solutionID, "secrets.json"}
+}
+
+// vaultKey returns the key the vault is encrypted with. It's kept in the OS keychain, per
+// data directory, and created there on first use.
+func (s *SolutionService) vaultKey() ([]byte, error) {
+	if encoded := os.Getenv(vaultKeyEnv); encoded != "" {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not valid base64: %w", vaultKeyEnv, err)
+		}
+		return key, nil
+	}
+
+	vaultKeys.Lock()
+	defer vaultKeys.Unlock()
+	if key, ok := vaultKeys.keys[s.store.root]; ok {
+		return key, nil
+	}
+
+	user := "vault:" + s.store.root
+	encoded, err := keyring.Get(vaultKeyringService, user)
+	switch {
+	case err == nil:
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("vault key in the OS keychain is invalid: %w", err)
+		}
+		vaultKeys.keys[s.store.root] = key
+		return key, nil
+	case !errors.Is(err, keyring.ErrNotFound):
+		return nil, fmt.Errorf("failed to read vault key from the OS keychain, set %s where there is none: %w", vaultKeyEnv, err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to create vault key: %w", err)
+	}
+	if err := keyring.Set(vaultKeyringService, user, base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("failed to save vault key in the OS keychain, set %s where there is none: %w", vaultKeyEnv, err)
+	}
+	vaultKeys.keys[s.store.root] = key
+	return key, nil
+}
+
+// vaultCipher returns the cipher of the local vault
+func (s *SolutionService) vaultCipher() (cipher.AEAD, error) {
+	key, err := s.vaultKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("vault key is invalid: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *SolutionService) loadVault(solutionId string) (map[string]vaultEntry, error) {
+	entries := make(map[string]vaultEntry)
+	data, ok, err := s.store.Read(vaultPath(solutionId)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault: %w", err)
+	}
+	if !ok {
+		return entries, nil
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to read vault: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *SolutionService) saveVault(solutionId string, entries map[string]vaultEntry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := s.store.Write(data, vaultPath(solutionId)...); err != nil {
+		return fmt.Errorf("failed to save vault: %w", err)
+	}
+	return nil
+}
+
+// secretAAD binds a ciphertext to the secret it was stored as, so entries can't be swapped
+func secretAAD(solutionId string, name string) []byte {
+	return []byte(solutionId + "/" + name)
+}
+
+// hasVaultSecret reports whether the vault of a solution holds the named secret
+func (s *SolutionService) hasVaultSecret(solutionId string, name string) bool {
+	entries, err := s.loadVault(solutionId)
+	if err != nil {
+		return false
+	}
+	_, ok := entries[name]
+	return ok
+}
+
+// readVaultSecret decrypts a secret from the vault of a solution
+func (s *SolutionService) readVaultSecret(solutionId string, name string) (string, error) {
+	entries, err := s.loadVault(solutionId)
+	if err != nil {
+		return "", err
+	}
+	entry, ok := entries[name]
+	if !ok {
+		return "", fmt.Errorf("secret %q not found in the vault", name)
+	}
+
+	aead, err := s.vaultCipher()
+	if err != nil {
+		return "", err
+	}
+	if len(entry.Ciphertext) < aead.NonceSize() {
+		return "", fmt.Errorf("secret %q is corrupt", name)
+	}
+	nonce, ciphertext := entry.Ciphertext[:aead.NonceSize()], entry.Ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, secretAAD(solutionId, name))
+	if err != nil {
+		return "", fmt.Errorf("secret %q could not be decrypted", name)
+	}
+	return string(plaintext), nil
+}
+
+// GetSecrets lists the secrets in the vault of a solution
+func (s *SolutionService) GetSecrets(solutionId string) ([]SecretInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.findSolution(solutionId) == nil {
+		return nil, fmt.Errorf("solution not found")
+	}
+	entries, err := s.loadVault(solutionId)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]SecretInfo, 0, len(entries))
+	for name, entry := range entries {
+		secrets = append(secrets, SecretInfo{Name: name, UpdatedAt: entry.UpdatedAt})
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+	return secrets, nil
+}
+
+// SetSecret encrypts a value and stores it in the vault of a solution, replacing any
+// existing secret with the same name. Configuration refers to it with secretRef.vault.
+func (s *SolutionService) SetSecret(solutionId string, name string, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setSecret(solutionId, name, value)
+}
+
+// setSecret is SetSecret for callers that already hold s.mu
+func (s *SolutionService) setSecret(solutionId string, name string, value string) error {
+	if s.findSolution(solutionId) == nil {
+		return fmt.Errorf("solution not found")
+	}
+	if !secretNamePattern.MatchString(name) {
+		return fmt.Errorf("secret name %q may only contain letters, digits, '.', '_' and '-'", name)
+	}
+
+	entries, err := s.loadVault(solutionId)
+	if err != nil {
+		return err
+	}
+	aead, err := s.vaultCipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+	entries[name] = vaultEntry{
+		Ciphertext: aead.Seal(nonce, nonce, []byte(value), secretAAD(solutionId, name)),
+		UpdatedAt:  time.Now(),
+	}
+	return s.saveVault(solutionId, entries)
+}
+
+// DeleteSecret removes a secret from the vault of a solution
+func (s *SolutionService) DeleteSecret(solutionId string, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findSolution(solutionId) == nil {
+		return fmt.Errorf("solution not found")
+	}
+	entries, err := s.loadVault(solutionId)
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[name]; !ok {
+		return fmt.Errorf("secret not found")
+	}
+	delete(entries, name)
+	return s.saveVault(solutionId, entries)
+}